@@ -11,12 +11,14 @@ import (
 
 type Migration interface {
 	AutoMigrate()
+	Seed()
 	SetDb(*gorm.DB)
 }
 
 type migration struct {
 	Db            *gorm.DB
 	DbModels      *[]interface{}
+	DbSeeders     *[]func(*gorm.DB) error
 	IsAutoMigrate bool
 }
 
@@ -30,6 +32,27 @@ func Init() {
 				&entity.AlternativeEntityModel{},
 				&entity.ScoreEntityModel{},
 				&entity.FinalScoreEntityModel{},
+				&entity.CriteriaMatrixEntityModel{},
+				&entity.CriteriaEntityModel{},
+				&entity.AlternativeValueEntityModel{},
+				&entity.SubCriteriaEntityModel{},
+				&entity.ExpertJudgmentEntityModel{},
+				&entity.AlternativeMatrixEntityModel{},
+				&entity.CriteriaDependencyEntityModel{},
+				&entity.ValueFunctionEntityModel{},
+			},
+			DbSeeders: &[]func(*gorm.DB) error{
+				SeedCriteria,
+				SeedSubCriteria,
+				MigrateAlternativeValues,
+				MigrateScores,
+				MigrateCriteriaMatrixIndex,
+				MigrateDefaultCriteriaMatrix,
+				MigrateSubCriteriaLabels,
+				MigrateSubCriteriaBins,
+				MigrateSubCriteriaCodes,
+				MigrateSubCriteriaWeights,
+				MigrateSubCriteriaBinEdges,
 			},
 			IsAutoMigrate: true,
 		},
@@ -42,6 +65,7 @@ func Init() {
 		} else {
 			v.SetDb(dbConnection)
 			v.AutoMigrate()
+			v.Seed()
 			logrus.Info(fmt.Sprintf("Successfully run migration for database %s", k))
 		}
 	}
@@ -54,6 +78,17 @@ func (m *migration) AutoMigrate() {
 	}
 }
 
+func (m *migration) Seed() {
+	if m.DbSeeders == nil {
+		return
+	}
+	for _, seeder := range *m.DbSeeders {
+		if err := seeder(m.Db); err != nil {
+			logrus.Error(fmt.Sprintf("Failed to run seeder, %s", err.Error()))
+		}
+	}
+}
+
 func (m *migration) SetDb(db *gorm.DB) {
 	m.Db = db
 }
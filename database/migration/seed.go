@@ -0,0 +1,531 @@
+package migration
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"math"
+	"os"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+	"ta13-svc/pkg/utils/ahp"
+)
+
+var defaultCriterias = []entity.CriteriaEntity{
+	{Code: "timbulan_sampah", Name: "Timbulan Sampah", Sort: 1, Type: entity.CriteriaTypeBenefit},
+	{Code: "jarak_tpa", Name: "Jarak TPA", Sort: 2, Type: entity.CriteriaTypeBenefit},
+	{Code: "jarak_pemukiman", Name: "Jarak Pemukiman", Sort: 3, Type: entity.CriteriaTypeBenefit},
+	{Code: "jarak_sungai", Name: "Jarak Sungai", Sort: 4, Type: entity.CriteriaTypeBenefit},
+	{Code: "partisipasi_masyarakat", Name: "Partisipasi Masyarakat", Sort: 5, Type: entity.CriteriaTypeBenefit},
+	{Code: "cakupan_rumah", Name: "Cakupan Rumah", Sort: 6, Type: entity.CriteriaTypeBenefit},
+	{Code: "aksesibilitas", Name: "Aksesibilitas", Sort: 7, Type: entity.CriteriaTypeBenefit},
+}
+
+func SeedCriteria(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&entity.CriteriaEntityModel{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	for _, criteria := range defaultCriterias {
+		data := entity.CriteriaEntityModel{
+			Entity:         abstraction.Entity{ID: uuid.NewString()},
+			CriteriaEntity: criteria,
+		}
+		if err := db.Create(&data).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func SeedSubCriteria(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&entity.SubCriteriaEntityModel{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var criterias []entity.CriteriaEntityModel
+	if err := db.Find(&criterias).Error; err != nil {
+		return err
+	}
+
+	templates := ahp.DefaultSubCriteria()
+	for _, criteria := range criterias {
+		template, ok := templates[criteria.Code]
+		if !ok {
+			continue
+		}
+
+		subCriterias, _, err := entity.NewSubCriterias(criteria.ID, entity.SubCriteriaFromTemplate(template), template.Pairwise, "")
+		if err != nil {
+			return err
+		}
+
+		if err = db.Create(&subCriterias).Error; err != nil {
+			return err
+		}
+
+		if err = db.Model(&entity.CriteriaEntityModel{}).Where("id = ?", criteria.ID).
+			Update("sub_criteria_pairwise", entity.Matrix(template.Pairwise)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func MigrateAlternativeValues(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&entity.AlternativeEntityModel{}, defaultCriterias[0].Code) {
+		return nil
+	}
+
+	var criterias []entity.CriteriaEntityModel
+	if err := db.Find(&criterias).Error; err != nil {
+		return err
+	}
+
+	//MEMINDAHKAN KOLOM KRITERIA LAMA KE TABEL ALTERNATIVE_VALUES
+	for _, criteria := range criterias {
+		if !migrator.HasColumn(&entity.AlternativeEntityModel{}, criteria.Code) {
+			continue
+		}
+
+		rows, err := db.Table("alternatives").Select("id", criteria.Code).Rows()
+		if err != nil {
+			return err
+		}
+
+		values := make([]entity.AlternativeValueEntityModel, 0)
+		for rows.Next() {
+			var alternativeID string
+			var value sql.NullString
+			if err = rows.Scan(&alternativeID, &value); err != nil {
+				rows.Close()
+				return err
+			}
+			if !value.Valid || value.String == "" {
+				continue
+			}
+
+			values = append(values, entity.AlternativeValueEntityModel{
+				Entity:                 abstraction.Entity{ID: uuid.NewString()},
+				AlternativeValueEntity: entity.AlternativeValueEntity{Value: value.String},
+				AlternativeID:          alternativeID,
+				CriteriaID:             criteria.ID,
+			})
+		}
+		rows.Close()
+
+		if len(values) > 0 {
+			if err = db.Omit("Criteria").Create(&values).Error; err != nil {
+				return err
+			}
+		}
+
+		if err = migrator.DropColumn(&entity.AlternativeEntityModel{}, criteria.Code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func MigrateScores(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&entity.ScoreEntityModel{}, defaultCriterias[0].Code) {
+		return nil
+	}
+
+	//SKOR LAMA TIDAK MEMILIKI CRITERIA_ID, HAPUS DAN HITUNG ULANG
+	if err := db.Where("criteria_id IS NULL OR criteria_id = ''").Delete(&entity.ScoreEntityModel{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Model(&entity.CollectionEntityModel{}).Where("score_is_calculated = ?", true).
+		Update("score_is_calculated", false).Error; err != nil {
+		return err
+	}
+
+	for _, criteria := range defaultCriterias {
+		if !migrator.HasColumn(&entity.ScoreEntityModel{}, criteria.Code) {
+			continue
+		}
+		if err := migrator.DropColumn(&entity.ScoreEntityModel{}, criteria.Code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func MigrateCriteriaMatrixIndex(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	//INDEX LAMA HANYA SATU MATRIKS PER KOLEKSI, SEKARANG SATU MATRIKS PER NODE
+	if !migrator.HasIndex(&entity.CriteriaMatrixEntityModel{}, "idx_criteria_matrices_collection_id") {
+		return nil
+	}
+
+	return migrator.DropIndex(&entity.CriteriaMatrixEntityModel{}, "idx_criteria_matrices_collection_id")
+}
+
+func MigrateSubCriteriaBins(db *gorm.DB) error {
+	var criterias []entity.CriteriaEntityModel
+	if err := db.Preload("SubCriterias").Find(&criterias).Error; err != nil {
+		return err
+	}
+
+	templates := ahp.DefaultSubCriteria()
+	for _, criteria := range criterias {
+		template, ok := templates[criteria.Code]
+		if !ok || template.Bins == nil {
+			continue
+		}
+
+		//HANYA KRITERIA YANG BELUM MEMILIKI RENTANG SAMA SEKALI
+		binned := false
+		for _, subCriteria := range criteria.SubCriterias {
+			binned = binned || subCriteria.HasBin()
+		}
+		if binned {
+			continue
+		}
+
+		for i, label := range template.Labels {
+			if err := db.Model(&entity.SubCriteriaEntityModel{}).Where("criteria_id = ? AND label = ?", criteria.ID, label).
+				Updates(map[string]interface{}{"min": template.Bins[i].Min, "max": template.Bins[i].Max}).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// renamedSubCriteriaLabels maps old labels of the default sub criteria to their current label.
+var renamedSubCriteriaLabels = map[string]string{
+	"Lokasi memenuhi peli banjir":          "Lokasi memenuhi peil banjir",
+	"Lokasi memenuhi sebagian peli banjir": "Lokasi memenuhi sebagian peil banjir",
+	"Lokasi tidak memenuhi peli banjir":    "Lokasi tidak memenuhi peil banjir",
+	"61%-81% Masyarakat Setuju":            "61%-80% Masyarakat Setuju",
+}
+
+// renamedSubCriteriaLabelsEN maps old english labels of the default sub criteria to their current label.
+var renamedSubCriteriaLabelsEN = map[string]string{
+	"61%-81% of residents agree": "61%-80% of residents agree",
+}
+
+func MigrateSubCriteriaLabels(db *gorm.DB) error {
+	for old, label := range renamedSubCriteriaLabels {
+		if err := db.Model(&entity.SubCriteriaEntityModel{}).Where("label = ?", old).Update("label", label).Error; err != nil {
+			return err
+		}
+
+		//NILAI ALTERNATIF MENGIKUTI LABEL BARU
+		if err := db.Model(&entity.AlternativeValueEntityModel{}).Where("value = ?", old).Update("value", label).Error; err != nil {
+			return err
+		}
+	}
+
+	for old, labelEN := range renamedSubCriteriaLabelsEN {
+		if err := db.Model(&entity.SubCriteriaEntityModel{}).Where("label_en = ?", old).Update("label_en", labelEN).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func MigrateSubCriteriaCodes(db *gorm.DB) error {
+	var criterias []entity.CriteriaEntityModel
+	if err := db.Preload("SubCriterias").Find(&criterias).Error; err != nil {
+		return err
+	}
+
+	templates := ahp.DefaultSubCriteria()
+	for _, criteria := range criterias {
+		template := templates[criteria.Code]
+
+		codes := make(map[string]bool)
+		for _, subCriteria := range criteria.SubCriterias {
+			codes[subCriteria.Code] = subCriteria.Code != ""
+		}
+
+		for _, subCriteria := range criteria.SubCriterias {
+			if subCriteria.Code == "" {
+				//LABEL TEMPLATE MENDAPAT KODE TEMPLATE, LABEL LAIN DITURUNKAN DARI LABELNYA
+				code, labelEN := entity.SubCriteriaCode(subCriteria.Label), ""
+				for i, label := range template.Labels {
+					if label == subCriteria.Label {
+						code, labelEN = template.Codes[i], template.LabelsEN[i]
+					}
+				}
+				if code == "" {
+					code = "sub_criteria"
+				}
+				for base, k := code, 2; codes[code]; k++ {
+					code = fmt.Sprintf("%s_%d", base, k)
+				}
+				codes[code] = true
+
+				if err := db.Model(&entity.SubCriteriaEntityModel{}).Where("id = ?", subCriteria.ID).
+					Updates(map[string]interface{}{"code": code, "label_en": labelEN}).Error; err != nil {
+					return err
+				}
+				subCriteria.Code = code
+			}
+
+			//NILAI ALTERNATIF YANG MASIH BERUPA LABEL DIGANTI DENGAN KODENYA
+			if err := db.Model(&entity.AlternativeValueEntityModel{}).Where("criteria_id = ? AND value = ?", criteria.ID, subCriteria.Label).
+				Update("value", subCriteria.Code).Error; err != nil {
+				return err
+			}
+		}
+
+		//NILAI YANG BUKAN LABEL ATAUPUN KODE SUB KRITERIA DILAPORKAN
+		valid := make([]string, 0)
+		for code, ok := range codes {
+			if ok {
+				valid = append(valid, code)
+			}
+		}
+		query := db.Where("criteria_id = ?", criteria.ID)
+		if len(valid) > 0 {
+			query = query.Where("value NOT IN ?", valid)
+		}
+		var unconverted []entity.AlternativeValueEntityModel
+		if err := query.Find(&unconverted).Error; err != nil {
+			return err
+		}
+		for _, value := range unconverted {
+			logrus.Warn(fmt.Sprintf("Alternative %s has value %q on %s that matches no sub criteria, it was not converted to a code",
+				value.AlternativeID, value.Value, criteria.Code))
+		}
+	}
+
+	return nil
+}
+
+const defaultPairwisePath = "asset/pairwise.json"
+
+// MigrateDefaultCriteriaMatrix moves the default criteria template from the json file into criteria_matrices as
+// the goal matrix without collection, goal criteria compare equally when the file is gone.
+func MigrateDefaultCriteriaMatrix(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&entity.CriteriaMatrixEntityModel{}).Where("collection_id IS NULL AND node_id = ?", entity.GoalNodeID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var criterias []entity.CriteriaEntityModel
+	if err := db.Find(&criterias).Error; err != nil {
+		return err
+	}
+	pairwise := entity.NewEqualMatrix(len(entity.ChildCriterias(criterias, entity.GoalNodeID)))
+
+	jsonFile, err := os.ReadFile(defaultPairwisePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		var criteriaData entity.CriteriaData
+		if err = json.Unmarshal(jsonFile, &criteriaData); err != nil {
+			return err
+		}
+		pairwise = criteriaData.PairwiseFromJson
+	}
+
+	return db.Create(&entity.CriteriaMatrixEntityModel{
+		Entity:               abstraction.Entity{ID: uuid.NewString()},
+		CriteriaMatrixEntity: entity.CriteriaMatrixEntity{Pairwise: pairwise},
+		NodeID:               entity.GoalNodeID,
+	}).Error
+}
+
+// inventedSubCriteriaPairwise are the matrices first seeded for the sub criteria, their weights differ from the
+// legacy weights the scores were computed with.
+var inventedSubCriteriaPairwise = map[string]entity.Matrix{
+	"timbulan_sampah": {
+		{1, 3, 5, 5, 9, 9},
+		{1.0 / 3, 1, 3, 5, 5, 9},
+		{1.0 / 5, 1.0 / 3, 1, 3, 5, 5},
+		{1.0 / 5, 1.0 / 5, 1.0 / 3, 1, 3, 5},
+		{1.0 / 9, 1.0 / 5, 1.0 / 5, 1.0 / 3, 1, 3},
+		{1.0 / 9, 1.0 / 9, 1.0 / 5, 1.0 / 5, 1.0 / 3, 1},
+	},
+	"three_level": {
+		{1, 3, 9},
+		{1.0 / 3, 1, 5},
+		{1.0 / 9, 1.0 / 5, 1},
+	},
+	"five_level": {
+		{1, 3, 5, 7, 9},
+		{1.0 / 3, 1, 3, 5, 7},
+		{1.0 / 5, 1.0 / 3, 1, 3, 5},
+		{1.0 / 7, 1.0 / 5, 1.0 / 3, 1, 3},
+		{1.0 / 9, 1.0 / 7, 1.0 / 5, 1.0 / 3, 1},
+	},
+}
+
+// MigrateSubCriteriaWeights puts back the legacy weights of the sub criteria that still hold an invented matrix,
+// a matrix revised by the user is kept. Every restored criteria is logged.
+func MigrateSubCriteriaWeights(db *gorm.DB) error {
+	var criterias []entity.CriteriaEntityModel
+	if err := db.Preload("SubCriterias").Find(&criterias).Error; err != nil {
+		return err
+	}
+
+	templates := ahp.DefaultSubCriteria()
+	for _, criteria := range criterias {
+		template, ok := templates[criteria.Code]
+		legacy, hasLegacy := ahp.LegacySubCriteriaWeights()[criteria.Code]
+		if !ok || !hasLegacy {
+			continue
+		}
+
+		invented := inventedSubCriteriaPairwise[criteria.Code]
+		if invented == nil && len(legacy) == 3 {
+			invented = inventedSubCriteriaPairwise["three_level"]
+		}
+		if invented == nil && len(legacy) == 5 {
+			invented = inventedSubCriteriaPairwise["five_level"]
+		}
+		if !sameMatrix(criteria.SubCriteriaPairwise, invented) {
+			continue
+		}
+
+		criteriaData, err := entity.NewCriteriaData(template.Pairwise, "")
+		if err != nil {
+			return err
+		}
+
+		for i, code := range template.Codes {
+			if err = db.Model(&entity.SubCriteriaEntityModel{}).Where("criteria_id = ? AND code = ?", criteria.ID, code).
+				Update("weight", criteriaData.Criteria[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err = db.Model(&entity.CriteriaEntityModel{}).Where("id = ?", criteria.ID).
+			Update("sub_criteria_pairwise", entity.Matrix(template.Pairwise)).Error; err != nil {
+			return err
+		}
+
+		logrus.Info(fmt.Sprintf("Restored the legacy sub criteria weights of %s, collections scored since the invented matrix was seeded change once recalculated", criteria.Code))
+	}
+
+	return nil
+}
+
+func sameMatrix(a entity.Matrix, b entity.Matrix) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) > 1e-9 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// legacySubCriteriaBinEdges are the first seeded edges, one above the round numbers, a raw value between them
+// such as 100.5m fell in no range the labels describe.
+var legacySubCriteriaBinEdges = map[string][]float64{
+	"jarak_pemukiman":        {101, 201, 301, 401},
+	"partisipasi_masyarakat": {21, 41, 61, 81},
+	"cakupan_rumah":          {41, 81, 121, 161},
+}
+
+// MigrateSubCriteriaBinEdges moves the bins that still hold the legacy edges onto the round edges of the
+// template and rebins the stored raw values, bins revised by the user are kept.
+func MigrateSubCriteriaBinEdges(db *gorm.DB) error {
+	var criterias []entity.CriteriaEntityModel
+	if err := db.Preload("SubCriterias").Find(&criterias).Error; err != nil {
+		return err
+	}
+
+	templates := ahp.DefaultSubCriteria()
+	for _, criteria := range criterias {
+		template, ok := templates[criteria.Code]
+		edges, hasLegacy := legacySubCriteriaBinEdges[criteria.Code]
+		if !ok || !hasLegacy || len(criteria.SubCriterias) != len(template.Codes) {
+			continue
+		}
+
+		legacy := make(map[string]ahp.Bin)
+		for i, bin := range ahp.NewDescendingBins(edges...) {
+			legacy[template.Codes[i]] = bin
+		}
+		migrated := true
+		for _, subCriteria := range criteria.SubCriterias {
+			bin, ok := legacy[subCriteria.Code]
+			migrated = migrated && ok && sameBound(subCriteria.Min, bin.Min) && sameBound(subCriteria.Max, bin.Max)
+		}
+		if !migrated {
+			continue
+		}
+
+		for i, code := range template.Codes {
+			if err := db.Model(&entity.SubCriteriaEntityModel{}).Where("criteria_id = ? AND code = ?", criteria.ID, code).
+				Updates(map[string]interface{}{"min": template.Bins[i].Min, "max": template.Bins[i].Max}).Error; err != nil {
+				return err
+			}
+		}
+
+		//NILAI MENTAH DIPETAKAN ULANG KE RENTANG BARU
+		var subCriterias []entity.SubCriteriaEntityModel
+		if err := db.Where("criteria_id = ?", criteria.ID).Find(&subCriterias).Error; err != nil {
+			return err
+		}
+		var values []entity.AlternativeValueEntityModel
+		if err := db.Where("criteria_id = ? AND raw IS NOT NULL", criteria.ID).Find(&values).Error; err != nil {
+			return err
+		}
+		rebinned := 0
+		for _, value := range values {
+			code, err := entity.ResolveBin(subCriterias, *value.Raw)
+			if err != nil {
+				logrus.Warn(fmt.Sprintf("Alternative value %s of %s was not rebinned, %s", value.ID, criteria.Code, err.Error()))
+				continue
+			}
+			if code == value.Value {
+				continue
+			}
+			if err = db.Model(&entity.AlternativeValueEntityModel{}).Where("id = ?", value.ID).Update("value", code).Error; err != nil {
+				return err
+			}
+			rebinned++
+		}
+
+		logrus.Info(fmt.Sprintf("Moved the sub criteria bins of %s onto the round edges, %d raw values rebinned", criteria.Code, rebinned))
+	}
+
+	return nil
+}
+
+func sameBound(a *float64, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
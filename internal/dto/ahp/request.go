@@ -1,11 +1,77 @@
 package dto
 
-import "ta13-svc/internal/entity"
+import (
+	"ta13-svc/internal/entity"
+	"ta13-svc/pkg/utils/ahp"
+)
 
 type AHPByCollectionIDRequest struct {
 	CollectionID string `json:"collection_id" param:"collection_id" validate:"required"`
 }
 
+type AHPCalculateRequest struct {
+	AHPByCollectionIDRequest
+	Method string `query:"method" validate:"omitempty,weight_method"`
+}
+
+type AHPPointCalculateRequest struct {
+	AHPByCollectionIDRequest
+	Synthesis string `query:"synthesis" validate:"omitempty,oneof=distributive ideal topsis"`
+}
+
+type AHPScoreCalculateRequest struct {
+	AHPCalculateRequest
+	Synthesis string `query:"synthesis" validate:"omitempty,oneof=distributive ideal topsis"`
+}
+
+type AHPFinalScoreCalculateRequest struct {
+	AHPScoreCalculateRequest
+	TiePolicy string `query:"tie_policy" validate:"omitempty,oneof=dense competition fractional"`
+}
+
+type CriteriaGetRequest struct {
+	Method string `query:"method" validate:"omitempty,weight_method"`
+	Scale  string `query:"scale" validate:"omitempty,oneof=saaty balanced geometric"`
+}
+
 type CriteriaAlternativeUpdateRequest struct {
-	Pairwise entity.Matrix `json:"pairwise"`
+	entity.PairwiseInput
+	BestWorst  *entity.BestWorst `json:"best_worst"`
+	Method     string            `json:"method" validate:"omitempty,weight_method" example:"eigenvector"`
+	Completion string            `json:"completion" validate:"omitempty,oneof=harker llsm" example:"harker"`
+	Force      bool              `json:"force" example:"false"`
+}
+
+type SensitivityRequest struct {
+	AHPCalculateRequest
+	Steps int     `query:"steps" validate:"omitempty,min=1,max=100"`
+	Min   float64 `query:"min" validate:"omitempty,gte=0,lte=1"`
+	Max   float64 `query:"max" validate:"omitempty,gte=0,lte=1,gtefield=Min"`
+}
+
+type CriteriaDiagnosisRequest struct {
+	Method     string `query:"method" validate:"omitempty,weight_method"`
+	AutoRepair bool   `query:"auto_repair"`
+}
+
+type CriteriaPreferenceFunction struct {
+	CriteriaID string `json:"criteria_id" validate:"required"`
+	ahp.PreferenceFunction
+}
+
+type ComparisonRequest struct {
+	AHPByCollectionIDRequest
+	Method              string                       `json:"method" validate:"omitempty,weight_method" example:"eigenvector"`
+	Synthesis           string                       `json:"synthesis" validate:"omitempty,oneof=distributive ideal topsis" example:"distributive"`
+	PreferenceFunctions []CriteriaPreferenceFunction `json:"preference_functions" validate:"dive"`
+}
+
+type SMAARequest struct {
+	AHPScoreCalculateRequest
+	Samples       int     `query:"samples" validate:"omitempty,min=100,max=20000"`
+	Sampling      string  `query:"sampling" validate:"omitempty,oneof=dirichlet perturbation"`
+	Concentration float64 `query:"concentration" validate:"omitempty,gt=0"`
+	Spread        float64 `query:"spread" validate:"omitempty,gt=0,lte=2.2"`
+	Workers       int     `query:"workers" validate:"omitempty,min=1,max=8"`
+	Seed          *int64  `query:"seed"`
 }
@@ -1 +1,184 @@
 package dto
+
+import (
+	"ta13-svc/internal/entity"
+	"ta13-svc/pkg/response"
+	"ta13-svc/pkg/utils/ahp"
+)
+
+type SensitivityAlternative struct {
+	ID   string `json:"id"`
+	Nama string `json:"nama"`
+}
+
+type SensitivityStep struct {
+	Weight  float64   `json:"weight"`
+	Scores  []float64 `json:"scores"`
+	Ranking []string  `json:"ranking"`
+}
+
+type SensitivityRankReversal struct {
+	Weight float64 `json:"weight"`
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Rank   int     `json:"rank"`
+}
+
+type CriteriaSensitivity struct {
+	CriteriaID string                    `json:"criteria_id"`
+	Code       string                    `json:"code"`
+	Weight     float64                   `json:"weight"`
+	Steps      []SensitivityStep         `json:"steps"`
+	Thresholds []SensitivityRankReversal `json:"thresholds"`
+}
+
+type SensitivityResponse struct {
+	CollectionID string                   `json:"collection_id"`
+	Method       string                   `json:"method"`
+	Alternatives []SensitivityAlternative `json:"alternatives"`
+	Ranking      []string                 `json:"ranking"`
+	Criteria     []CriteriaSensitivity    `json:"criteria"`
+}
+type SensitivityResponseDoc struct {
+	Body struct {
+		Meta response.Meta       `json:"meta"`
+		Data SensitivityResponse `json:"data"`
+	} `json:"body"`
+}
+
+type RankReversalRemoval struct {
+	AlternativeID string   `json:"alternative_id"`
+	Nama          string   `json:"nama"`
+	Ranking       []string `json:"ranking"`
+	Preserved     bool     `json:"preserved"`
+}
+
+type RankReversalResponse struct {
+	CollectionID string                `json:"collection_id"`
+	Method       string                `json:"method"`
+	Synthesis    string                `json:"synthesis"`
+	Ranking      []string              `json:"ranking"`
+	RankReversal bool                  `json:"rank_reversal"`
+	Removals     []RankReversalRemoval `json:"removals"`
+}
+type RankReversalResponseDoc struct {
+	Body struct {
+		Meta response.Meta        `json:"meta"`
+		Data RankReversalResponse `json:"data"`
+	} `json:"body"`
+}
+
+type ComparisonAlternative struct {
+	ID            string  `json:"id"`
+	Nama          string  `json:"nama"`
+	AHPScore      float64 `json:"ahp_score"`
+	AHPRank       float64 `json:"ahp_rank"`
+	PositiveFlow  float64 `json:"positive_flow"`
+	NegativeFlow  float64 `json:"negative_flow"`
+	NetFlow       float64 `json:"net_flow"`
+	PrometheeRank float64 `json:"promethee_rank"`
+}
+
+type ComparisonResponse struct {
+	CollectionID        string                       `json:"collection_id"`
+	Method              string                       `json:"method"`
+	Synthesis           string                       `json:"synthesis"`
+	PreferenceFunctions []CriteriaPreferenceFunction `json:"preference_functions"`
+	Alternatives        []ComparisonAlternative      `json:"alternatives"`
+	AHPRanking          []string                     `json:"ahp_ranking"`
+	PrometheeRanking    []string                     `json:"promethee_ranking"`
+	AHPWinner           string                       `json:"ahp_winner"`
+	PrometheeWinner     string                       `json:"promethee_winner"`
+	SameWinner          bool                         `json:"same_winner"`
+	SameRanking         bool                         `json:"same_ranking"`
+}
+type ComparisonResponseDoc struct {
+	Body struct {
+		Meta response.Meta      `json:"meta"`
+		Data ComparisonResponse `json:"data"`
+	} `json:"body"`
+}
+
+type SMAAAlternative struct {
+	ID             string    `json:"id"`
+	Nama           string    `json:"nama"`
+	Acceptability  []float64 `json:"acceptability"`
+	CentralWeights []float64 `json:"central_weights"`
+}
+
+type SMAAResponse struct {
+	CollectionID  string            `json:"collection_id"`
+	Method        string            `json:"method"`
+	Synthesis     string            `json:"synthesis"`
+	Sampling      string            `json:"sampling"`
+	Samples       int               `json:"samples"`
+	Concentration float64           `json:"concentration,omitempty"`
+	Spread        float64           `json:"spread,omitempty"`
+	Seed          int64             `json:"seed"`
+	Codes         []string          `json:"codes"`
+	Weights       []float64         `json:"weights"`
+	Alternatives  []SMAAAlternative `json:"alternatives"`
+}
+type SMAAResponseDoc struct {
+	Body struct {
+		Meta response.Meta `json:"meta"`
+		Data SMAAResponse  `json:"data"`
+	} `json:"body"`
+}
+
+type JudgmentDiagnosis struct {
+	ahp.JudgmentSuggestion
+	RowCode string `json:"row_code"`
+	ColCode string `json:"col_code"`
+}
+
+type CriteriaRepair struct {
+	Applied    []JudgmentDiagnosis `json:"applied"`
+	Repaired   entity.CriteriaData `json:"repaired"`
+	Consistent bool                `json:"consistent"`
+	Message    string              `json:"message,omitempty"`
+}
+
+type CriteriaDiagnosisResponse struct {
+	entity.CriteriaData
+	Suggestions []JudgmentDiagnosis `json:"suggestions"`
+	Repair      *CriteriaRepair     `json:"repair,omitempty"`
+}
+type CriteriaDiagnosisResponseDoc struct {
+	Body struct {
+		Meta response.Meta             `json:"meta"`
+		Data CriteriaDiagnosisResponse `json:"data"`
+	} `json:"body"`
+}
+
+type SubCriteriaLabel struct {
+	ID string `json:"id" example:"Perumahan"`
+	EN string `json:"en" example:"Housing"`
+}
+
+type SubCriteriaOption struct {
+	Code   string           `json:"code" example:"perumahan"`
+	Label  SubCriteriaLabel `json:"label"`
+	Weight float64          `json:"weight"`
+	Sort   int              `json:"sort" example:"1"`
+	Min    *float64         `json:"min,omitempty"`
+	Max    *float64         `json:"max,omitempty"`
+}
+
+type SubCriteriaCatalogCriteria struct {
+	CriteriaID string              `json:"criteria_id"`
+	Code       string              `json:"code" example:"timbulan_sampah"`
+	Name       string              `json:"name" example:"Timbulan Sampah"`
+	Sort       int                 `json:"sort" example:"1"`
+	Options    []SubCriteriaOption `json:"options"`
+}
+
+type SubCriteriaCatalogResponse struct {
+	Criterias []SubCriteriaCatalogCriteria `json:"criterias"`
+}
+type SubCriteriaCatalogResponseDoc struct {
+	Body struct {
+		Meta response.Meta              `json:"meta"`
+		Data SubCriteriaCatalogResponse `json:"data"`
+	} `json:"body"`
+}
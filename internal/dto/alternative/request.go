@@ -14,12 +14,16 @@ type AlternativeGetByIDRequest struct {
 
 type AlternativeCreateRequest struct {
 	entity.AlternativeEntity
-	CollectionID string `json:"collection_id"`
+	CollectionID string             `json:"collection_id"`
+	Values       map[string]string  `json:"values" example:"jarak_sungai:memenuhi_peil_banjir"`
+	RawValues    map[string]float64 `json:"raw_values" example:"jarak_pemukiman:350"`
 }
 
 type AlternativeUpdateRequest struct {
 	ID string `param:"id" validate:"required"`
 	entity.AlternativeEntity
+	Values    map[string]string  `json:"values" example:"jarak_sungai:memenuhi_peil_banjir"`
+	RawValues map[string]float64 `json:"raw_values" example:"jarak_pemukiman:350"`
 }
 
 type AlternativeDeleteRequest struct {
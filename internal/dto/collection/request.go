@@ -25,3 +25,105 @@ type CollectionDeleteRequest struct {
 	ID string `param:"id" validate:"required"`
 	entity.CollectionEntity
 }
+
+type CollectionCriteriaGetRequest struct {
+	ID     string `param:"id" validate:"required"`
+	NodeID string `query:"node_id"`
+	Method string `query:"method" validate:"omitempty,weight_method"`
+	Scale  string `query:"scale" validate:"omitempty,oneof=saaty balanced geometric"`
+}
+
+type CollectionCriteriaUpdateRequest struct {
+	ID     string `param:"id" validate:"required"`
+	NodeID string `json:"node_id" example:"id of the cluster criteria, empty for the goal"`
+	entity.PairwiseInput
+	FuzzyPairwise entity.FuzzyMatrix `json:"fuzzy_pairwise"`
+	BestWorst     *entity.BestWorst  `json:"best_worst"`
+	Method        string             `json:"method" validate:"omitempty,weight_method" example:"fuzzy_extent"`
+	Completion    string             `json:"completion" validate:"omitempty,oneof=harker llsm" example:"harker"`
+	Force         bool               `json:"force" example:"false"`
+}
+
+type CollectionJudgmentsGetRequest struct {
+	ID          string `param:"id" validate:"required"`
+	NodeID      string `query:"node_id"`
+	Method      string `query:"method" validate:"omitempty,weight_method"`
+	Aggregation string `query:"aggregation" validate:"omitempty,oneof=aij aip"`
+}
+
+type CollectionJudgmentUpdateRequest struct {
+	ID     string `param:"id" validate:"required"`
+	UserID string `param:"user_id" validate:"required"`
+	NodeID string `json:"node_id" example:"id of the cluster criteria, empty for the goal"`
+	entity.PairwiseInput
+	Weight     float64 `json:"weight" validate:"gte=0" example:"1"`
+	Method     string  `json:"method" validate:"omitempty,weight_method" example:"eigenvector"`
+	Completion string  `json:"completion" validate:"omitempty,oneof=harker llsm" example:"harker"`
+	Force      bool    `json:"force" example:"false"`
+}
+
+type CollectionJudgmentDeleteRequest struct {
+	ID     string `param:"id" validate:"required"`
+	UserID string `param:"user_id" validate:"required"`
+	NodeID string `query:"node_id"`
+}
+
+type CollectionJudgmentsAggregateRequest struct {
+	ID          string `param:"id" validate:"required"`
+	NodeID      string `json:"node_id" example:"id of the cluster criteria, empty for the goal"`
+	Method      string `json:"method" validate:"omitempty,weight_method" example:"eigenvector"`
+	Aggregation string `json:"aggregation" validate:"omitempty,oneof=aij aip" example:"aij"`
+	Force       bool   `json:"force" example:"false"`
+}
+
+type CollectionAlternativeMatricesGetRequest struct {
+	ID     string `param:"id" validate:"required"`
+	Method string `query:"method" validate:"omitempty,weight_method"`
+}
+
+type CollectionAlternativeMatrixUpdateRequest struct {
+	ID             string   `param:"id" validate:"required"`
+	CriteriaID     string   `param:"criteria_id" validate:"required"`
+	AlternativeIDs []string `json:"alternative_ids" example:"order of the matrix rows, defaults to the order returned by the get endpoint"`
+	entity.PairwiseInput
+	Method     string `json:"method" validate:"omitempty,weight_method" example:"eigenvector"`
+	Completion string `json:"completion" validate:"omitempty,oneof=harker llsm" example:"harker"`
+	Force      bool   `json:"force" example:"false"`
+}
+
+type CollectionDependenciesGetRequest struct {
+	ID     string `param:"id" validate:"required"`
+	Method string `query:"method" validate:"omitempty,weight_method"`
+}
+
+type CollectionDependencyUpdateRequest struct {
+	ID         string `param:"id" validate:"required"`
+	CriteriaID string `param:"criteria_id" validate:"required"`
+	ClusterID  string `json:"cluster_id" example:"id of the cluster criteria whose children are compared, empty for the goal"`
+	entity.PairwiseInput
+	Weight     float64 `json:"weight" validate:"omitempty,gt=0" example:"1"`
+	Method     string  `json:"method" validate:"omitempty,weight_method" example:"eigenvector"`
+	Completion string  `json:"completion" validate:"omitempty,oneof=harker llsm" example:"harker"`
+	Force      bool    `json:"force" example:"false"`
+}
+
+type CollectionDependencyDeleteRequest struct {
+	ID         string `param:"id" validate:"required"`
+	CriteriaID string `param:"criteria_id" validate:"required"`
+	ClusterID  string `query:"cluster_id"`
+}
+
+type CollectionValueFunctionsGetRequest struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CollectionValueFunctionUpdateRequest struct {
+	ID         string `param:"id" validate:"required"`
+	CriteriaID string `param:"criteria_id" validate:"required"`
+	entity.ValueFunctionEntity
+}
+
+type CollectionValueFunctionDeleteRequest struct {
+	ID         string `param:"id" validate:"required"`
+	CriteriaID string `param:"criteria_id" validate:"required"`
+}
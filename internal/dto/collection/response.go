@@ -3,6 +3,7 @@ package dto
 import (
 	"ta13-svc/internal/entity"
 	"ta13-svc/pkg/response"
+	"ta13-svc/pkg/utils/ahp"
 )
 
 type CollectionsGetResponse struct {
@@ -54,3 +55,155 @@ type CollectionDeleteResponseDoc struct {
 		Data CollectionDeleteResponse `json:"data"`
 	} `json:"body"`
 }
+
+type CollectionCriteriaResponse struct {
+	entity.CriteriaData
+	CollectionID string `json:"collection_id"`
+	NodeID       string `json:"node_id"`
+	IsDefault    bool   `json:"is_default"`
+}
+type CollectionCriteriaResponseDoc struct {
+	Body struct {
+		Meta response.Meta              `json:"meta"`
+		Data CollectionCriteriaResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionJudgmentResponse struct {
+	entity.ExpertCriteriaData
+	CollectionID string `json:"collection_id"`
+	NodeID       string `json:"node_id"`
+}
+type CollectionJudgmentResponseDoc struct {
+	Body struct {
+		Meta response.Meta              `json:"meta"`
+		Data CollectionJudgmentResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionJudgmentsResponse struct {
+	entity.GroupCriteriaData
+	CollectionID string `json:"collection_id"`
+	NodeID       string `json:"node_id"`
+}
+type CollectionJudgmentsResponseDoc struct {
+	Body struct {
+		Meta response.Meta               `json:"meta"`
+		Data CollectionJudgmentsResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionJudgmentDeleteResponse struct {
+	CollectionID string `json:"collection_id"`
+	UserID       string `json:"user_id"`
+	NodeID       string `json:"node_id"`
+}
+type CollectionJudgmentDeleteResponseDoc struct {
+	Body struct {
+		Meta response.Meta                    `json:"meta"`
+		Data CollectionJudgmentDeleteResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionAlternativeMatrixResponse struct {
+	entity.CriteriaData
+	CollectionID   string   `json:"collection_id"`
+	CriteriaID     string   `json:"criteria_id"`
+	CriteriaCode   string   `json:"criteria_code"`
+	AlternativeIDs []string `json:"alternative_ids"`
+	IsDefault      bool     `json:"is_default"`
+}
+type CollectionAlternativeMatrixResponseDoc struct {
+	Body struct {
+		Meta response.Meta                       `json:"meta"`
+		Data CollectionAlternativeMatrixResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionAlternativeMatricesResponse struct {
+	CollectionID string                                `json:"collection_id"`
+	ScoringMode  string                                `json:"scoring_mode"`
+	Matrices     []CollectionAlternativeMatrixResponse `json:"matrices"`
+}
+type CollectionAlternativeMatricesResponseDoc struct {
+	Body struct {
+		Meta response.Meta                         `json:"meta"`
+		Data CollectionAlternativeMatricesResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionDependencyResponse struct {
+	entity.CriteriaData
+	CollectionID string  `json:"collection_id"`
+	CriteriaID   string  `json:"criteria_id"`
+	CriteriaCode string  `json:"criteria_code"`
+	ClusterID    string  `json:"cluster_id"`
+	Dependency   string  `json:"dependency" example:"inner"`
+	Weight       float64 `json:"weight"`
+}
+type CollectionDependencyResponseDoc struct {
+	Body struct {
+		Meta response.Meta                `json:"meta"`
+		Data CollectionDependencyResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionDependenciesResponse struct {
+	CollectionID  string                         `json:"collection_id"`
+	DecisionModel string                         `json:"decision_model"`
+	Dependencies  []CollectionDependencyResponse `json:"dependencies"`
+}
+type CollectionDependenciesResponseDoc struct {
+	Body struct {
+		Meta response.Meta                  `json:"meta"`
+		Data CollectionDependenciesResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionDependencyDeleteResponse struct {
+	CollectionID string `json:"collection_id"`
+	CriteriaID   string `json:"criteria_id"`
+	ClusterID    string `json:"cluster_id"`
+}
+type CollectionDependencyDeleteResponseDoc struct {
+	Body struct {
+		Meta response.Meta                      `json:"meta"`
+		Data CollectionDependencyDeleteResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionValueFunctionResponse struct {
+	entity.ValueFunctionEntity
+	CollectionID string           `json:"collection_id"`
+	CriteriaID   string           `json:"criteria_id"`
+	CriteriaCode string           `json:"criteria_code"`
+	Preview      []ahp.ValuePoint `json:"preview"`
+}
+type CollectionValueFunctionResponseDoc struct {
+	Body struct {
+		Meta response.Meta                   `json:"meta"`
+		Data CollectionValueFunctionResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionValueFunctionsResponse struct {
+	CollectionID   string                            `json:"collection_id"`
+	ValueFunctions []CollectionValueFunctionResponse `json:"value_functions"`
+}
+type CollectionValueFunctionsResponseDoc struct {
+	Body struct {
+		Meta response.Meta                    `json:"meta"`
+		Data CollectionValueFunctionsResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CollectionValueFunctionDeleteResponse struct {
+	CollectionID string `json:"collection_id"`
+	CriteriaID   string `json:"criteria_id"`
+}
+type CollectionValueFunctionDeleteResponseDoc struct {
+	Body struct {
+		Meta response.Meta                         `json:"meta"`
+		Data CollectionValueFunctionDeleteResponse `json:"data"`
+	} `json:"body"`
+}
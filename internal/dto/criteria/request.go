@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"ta13-svc/internal/entity"
+)
+
+type CriteriaGetByIDRequest struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CriteriaCreateRequest struct {
+	entity.CriteriaEntity
+	SubCriterias        []entity.SubCriteriaEntity `json:"sub_criterias" validate:"omitempty,dive"`
+	SubCriteriaPairwise entity.Matrix              `json:"sub_criteria_pairwise"`
+}
+
+type CriteriaUpdateRequest struct {
+	ID string `param:"id" validate:"required"`
+	entity.CriteriaEntity
+}
+
+type CriteriaDeleteRequest struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type SubCriteriaGetRequest struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type SubCriteriaUpdateRequest struct {
+	ID           string              `param:"id" validate:"required"`
+	SubCriterias []SubCriteriaUpdate `json:"sub_criterias" validate:"required,dive"`
+	Pairwise     entity.Matrix       `json:"pairwise" validate:"required"`
+	Method       string              `json:"method" validate:"omitempty,weight_method" example:"average"`
+	Force        bool                `json:"force" example:"false"`
+}
+
+// SubCriteriaUpdate is a sub criteria of the payload, the id of a stored sub criteria keeps its code.
+type SubCriteriaUpdate struct {
+	ID string `json:"id" example:"3f0a4c1e-8a52-4a3b-9d0e-2f6f1c7b9e11"`
+	entity.SubCriteriaEntity
+}
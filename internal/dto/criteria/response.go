@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"ta13-svc/internal/entity"
+	"ta13-svc/pkg/response"
+)
+
+type CriteriasGetResponseDoc struct {
+	Body struct {
+		Meta response.Meta                `json:"meta"`
+		Data []entity.CriteriaEntityModel `json:"data"`
+	} `json:"body"`
+}
+
+type CriteriaGetByIDResponse struct {
+	entity.CriteriaEntityModel
+}
+type CriteriaGetByIDResponseDoc struct {
+	Body struct {
+		Meta response.Meta           `json:"meta"`
+		Data CriteriaGetByIDResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CriteriaCreateResponse struct {
+	entity.CriteriaEntityModel
+	Warnings []string `json:"warnings,omitempty"`
+}
+type CriteriaCreateResponseDoc struct {
+	Body struct {
+		Meta response.Meta          `json:"meta"`
+		Data CriteriaCreateResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CriteriaUpdateResponse struct {
+	entity.CriteriaEntityModel
+}
+type CriteriaUpdateResponseDoc struct {
+	Body struct {
+		Meta response.Meta          `json:"meta"`
+		Data CriteriaUpdateResponse `json:"data"`
+	} `json:"body"`
+}
+
+type CriteriaDeleteResponse struct {
+	ID *string `json:"id"`
+}
+type CriteriaDeleteResponseDoc struct {
+	Body struct {
+		Meta response.Meta          `json:"meta"`
+		Data CriteriaDeleteResponse `json:"data"`
+	} `json:"body"`
+}
+
+type SubCriteriaResponse struct {
+	CriteriaID              string                          `json:"criteria_id"`
+	SubCriterias            []entity.SubCriteriaEntityModel `json:"sub_criterias"`
+	Pairwise                entity.Matrix                   `json:"pairwise"`
+	Method                  string                          `json:"method"`
+	LambdaMax               float64                         `json:"lambda_max"`
+	ConsistencyIndex        float64                         `json:"consistency_index"`
+	ConsistencyRatio        float64                         `json:"consistency_ratio"`
+	IsConsistent            bool                            `json:"is_consistent"`
+	RebinnedValues          int                             `json:"rebinned_values"`
+	RecalculatedCollections []string                        `json:"recalculated_collections,omitempty"`
+	FailedCollections       []string                        `json:"failed_collections,omitempty"`
+	StaleCollections        []string                        `json:"stale_collections,omitempty"`
+}
+type SubCriteriaResponseDoc struct {
+	Body struct {
+		Meta response.Meta       `json:"meta"`
+		Data SubCriteriaResponse `json:"data"`
+	} `json:"body"`
+}
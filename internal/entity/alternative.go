@@ -8,23 +8,17 @@ import (
 )
 
 type AlternativeEntity struct {
-	Nama                  string `json:"nama" example:"nama"`
-	TimbulanSampah        string `json:"timbulan_sampah" example:"Jaringan Jalan"`
-	JarakTpa              string `json:"jarak_tpa" example:"Alternatif berada di jangkauan layanan TPA"`
-	JarakPemukiman        string `json:"jarak_pemukiman" example:"0m-100m"`
-	JarakSungai           string `json:"jarak_sungai" example:"Lokasi memenuhi peil banjir"`
-	PartisipasiMasyarakat string `json:"partisipasi_masyarakat" example:"< 20% Masyarakat Setuju"`
-	CakupanRumah          string `json:"cakupan_rumah" example:"<40 Rumah"`
-	Aksesibilitas         string `json:"aksesibilitas" example:"Kondisi jalan bagus dan bisa dilewati kendaraan pengangkut sampah"`
-	Sort                  int8   `json:"sort"`
+	Nama string `json:"nama" example:"nama"`
+	Sort int8   `json:"sort"`
 }
 
 type AlternativeEntityModel struct {
 	abstraction.Entity
 	AlternativeEntity
-	CollectionID string                `json:"collection_id" gorm:"size:191"`
-	Score        ScoreEntityModel      `json:"scores" gorm:"foreignKey:AlternativeID;constraint:OnDelete:CASCADE;"`
-	FinalScore   FinalScoreEntityModel `json:"final_scores" gorm:"foreignKey:AlternativeID;constraint:OnDelete:CASCADE;"`
+	CollectionID string                        `json:"collection_id" gorm:"size:191"`
+	Values       []AlternativeValueEntityModel `json:"values" gorm:"foreignKey:AlternativeID;constraint:OnDelete:CASCADE;"`
+	Scores       []ScoreEntityModel            `json:"scores" gorm:"foreignKey:AlternativeID;constraint:OnDelete:CASCADE;"`
+	FinalScore   FinalScoreEntityModel         `json:"final_scores" gorm:"foreignKey:AlternativeID;constraint:OnDelete:CASCADE;"`
 }
 
 func (AlternativeEntityModel) TableName() string {
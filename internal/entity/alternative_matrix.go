@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"sort"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+const (
+	ScoringModeRatings  = "ratings"
+	ScoringModePairwise = "pairwise"
+)
+
+type StringList []string
+
+type AlternativeMatrixEntity struct {
+	Pairwise       Matrix     `json:"pairwise" gorm:"type:text"`
+	AlternativeIDs StringList `json:"alternative_ids" gorm:"type:text"`
+}
+
+type AlternativeMatrixEntityModel struct {
+	abstraction.Entity
+	AlternativeMatrixEntity
+	CollectionID string `json:"collection_id" gorm:"size:191;uniqueIndex:idx_collection_criteria"`
+	CriteriaID   string `json:"criteria_id" gorm:"size:191;uniqueIndex:idx_collection_criteria"`
+}
+
+func (AlternativeMatrixEntityModel) TableName() string {
+	return "alternative_matrices"
+}
+
+func (m *AlternativeMatrixEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *AlternativeMatrixEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
+
+// NewEqualAlternativeMatrix is the matrix of a criteria whose alternatives were never compared, they all compare
+// equally.
+func NewEqualAlternativeMatrix(alternativeIDs []string) AlternativeMatrixEntity {
+	return AlternativeMatrixEntity{
+		Pairwise:       NewEqualMatrix(len(alternativeIDs)),
+		AlternativeIDs: alternativeIDs,
+	}
+}
+
+// CriteriaData computes the local priorities of the alternatives in the given order, the matrix must compare
+// every one of them. The rows and columns of the other alternatives are dropped, so a subset of the
+// alternatives is prioritized from the judgments among themselves.
+func (m AlternativeMatrixEntity) CriteriaData(alternativeIDs []string, method string) (*CriteriaData, error) {
+	index := make(map[string]int)
+	for i, id := range m.AlternativeIDs {
+		index[id] = i
+	}
+
+	//MENYUSUN ULANG MATRIKS SESUAI URUTAN ALTERNATIF
+	pairwise := make(Matrix, len(alternativeIDs))
+	for i, a := range alternativeIDs {
+		row, ok := index[a]
+		if !ok {
+			return nil, fmt.Errorf("alternative %s is not compared in the pairwise matrix", a)
+		}
+		pairwise[i] = make([]float64, len(alternativeIDs))
+		for j, b := range alternativeIDs {
+			pairwise[i][j] = m.Pairwise[row][index[b]]
+		}
+	}
+
+	return NewCriteriaData(pairwise, method)
+}
+
+// Remap moves the judgments onto the alternatives newIDs, a removed alternative loses its row and column and a
+// new alternative compares equally with every other alternative.
+func (m AlternativeMatrixEntity) Remap(newIDs []string) AlternativeMatrixEntity {
+	index := make(map[string]int)
+	for i, id := range m.AlternativeIDs {
+		index[id] = i
+	}
+
+	remapped := AlternativeMatrixEntity{
+		Pairwise:       NewEqualMatrix(len(newIDs)),
+		AlternativeIDs: append(StringList{}, newIDs...),
+	}
+	for i, a := range newIDs {
+		for j, b := range newIDs {
+			oi, okRow := index[a]
+			oj, okCol := index[b]
+			if okRow && okCol && oi < len(m.Pairwise) && oj < len(m.Pairwise[oi]) {
+				remapped.Pairwise[i][j] = m.Pairwise[oi][oj]
+			}
+		}
+	}
+
+	return remapped
+}
+
+// SortedAlternativeIDs returns the alternative ids ordered by sort then id, the default row order of a matrix.
+func SortedAlternativeIDs(alternatives []AlternativeEntityModel) []string {
+	sorted := append([]AlternativeEntityModel{}, alternatives...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		if sorted[a].Sort != sorted[b].Sort {
+			return sorted[a].Sort < sorted[b].Sort
+		}
+		return sorted[a].ID < sorted[b].ID
+	})
+
+	ids := make([]string, len(sorted))
+	for i, alternative := range sorted {
+		ids[i] = alternative.ID
+	}
+	return ids
+}
+
+func (l StringList) Value() (driver.Value, error) {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	case nil:
+		*l = nil
+		return nil
+	}
+	return errors.New("unsupported type for string list")
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"math"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"testing"
+)
+
+func TestRemoveAlternativePairwise(t *testing.T) {
+	stored := AlternativeMatrixEntity{
+		Pairwise: Matrix{
+			{1, 3, 5},
+			{1.0 / 3, 1, 2},
+			{1.0 / 5, 1.0 / 2, 1},
+		},
+		AlternativeIDs: StringList{"a", "b", "c"},
+	}
+
+	//TANPA b, PRIORITAS DARI PERBANDINGAN a DAN c SAJA
+	reduced, err := stored.CriteriaData([]string{"c", "a"}, ahp.MethodEigenvector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := NewCriteriaData(Matrix{{1, 1.0 / 5}, {5, 1}}, ahp.MethodEigenvector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range expected.Criteria {
+		if math.Abs(reduced.Criteria[i]-expected.Criteria[i]) > 1e-9 {
+			t.Errorf("priority %d: expected %v, got %v", i, expected.Criteria[i], reduced.Criteria[i])
+		}
+	}
+
+	if _, err = stored.CriteriaData([]string{"a", "d"}, ahp.MethodEigenvector); err == nil {
+		t.Error("expected an error for an alternative that is not compared")
+	}
+}
+
+func TestRemoveAlternativeRatings(t *testing.T) {
+	//INTENSITAS SUB KRITERIA TERBAIK 0.6 DAN 0.5, ALTERNATIF TERBAIK TIDAK MENCAPAINYA
+	ideals := []float64{0.6, 0.5}
+	matrix := [][]float64{
+		{0.3, 0.25},
+		{0.1, 0.25},
+		{0.1, 0.1},
+	}
+
+	full, err := ahp.Synthesize(matrix, ahp.SynthesisIdeal, ideals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reduced, err := ahp.Synthesize(matrix[1:], ahp.SynthesisIdeal, ideals)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range reduced {
+		for j := range reduced[i] {
+			if reduced[i][j] != full[i+1][j] {
+				t.Errorf("[%d][%d] changed from %v to %v after removing the best alternative", i, j, full[i+1][j], reduced[i][j])
+			}
+		}
+	}
+	if full[0][0] != 0.5 {
+		t.Errorf("expected 0.3 / 0.6, got %v", full[0][0])
+	}
+}
+
+func TestSortedAlternativeIDsBreaksTiesByID(t *testing.T) {
+	alternatives := []AlternativeEntityModel{
+		{Entity: abstraction.Entity{ID: "c"}, AlternativeEntity: AlternativeEntity{Sort: 1}},
+		{Entity: abstraction.Entity{ID: "b"}, AlternativeEntity: AlternativeEntity{Sort: 2}},
+		{Entity: abstraction.Entity{ID: "a"}, AlternativeEntity: AlternativeEntity{Sort: 1}},
+	}
+
+	ids := SortedAlternativeIDs(alternatives)
+	if ids[0] != "a" || ids[1] != "c" || ids[2] != "b" {
+		t.Errorf("expected [a c b], got %v", ids)
+	}
+}
+
+func TestEqualAlternativeMatrixWithoutStoredJudgments(t *testing.T) {
+	ids := []string{"a", "b", "c", "d"}
+	for _, method := range ahp.WeightMethods {
+		criteriaData, err := NewEqualAlternativeMatrix(ids).CriteriaData(ids, method)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		for _, weight := range criteriaData.Criteria {
+			if math.Abs(weight-0.25) > 1e-9 {
+				t.Errorf("%s: expected equal priorities, got %v", method, criteriaData.Criteria)
+				break
+			}
+		}
+		if math.Abs(criteriaData.ConsistencyRatio) > 1e-9 {
+			t.Errorf("%s: expected CR 0, got %v", method, criteriaData.ConsistencyRatio)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+type AlternativeValueEntity struct {
+	Value string   `json:"value" example:"perumahan"`
+	Raw   *float64 `json:"raw" example:"350"`
+}
+
+type AlternativeValueEntityModel struct {
+	abstraction.Entity
+	AlternativeValueEntity
+	AlternativeID string              `json:"alternative_id" gorm:"size:191;uniqueIndex:idx_alternative_criteria"`
+	CriteriaID    string              `json:"criteria_id" gorm:"size:191;uniqueIndex:idx_alternative_criteria"`
+	Criteria      CriteriaEntityModel `json:"criteria" gorm:"foreignKey:CriteriaID;constraint:OnDelete:CASCADE;"`
+}
+
+func (AlternativeValueEntityModel) TableName() string {
+	return "alternative_values"
+}
+
+func (m *AlternativeValueEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *AlternativeValueEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"ta13-svc/pkg/utils/ahp"
+)
+
+// BestWorst holds the judgments of the Best-Worst Method, best and worst index the criteria in the order of
+// criteria_codes.
+type BestWorst struct {
+	Best          int       `json:"best" example:"1"`
+	Worst         int       `json:"worst" example:"4"`
+	BestToOthers  []float64 `json:"best_to_others" example:"2,1,4,2,8"`
+	OthersToWorst []float64 `json:"others_to_worst" example:"4,8,4,2,1"`
+}
+
+func (b BestWorst) Validate(field string, n int) []ahp.InputError {
+	return ahp.ValidateBestWorst(field, n, b.Best, b.Worst, b.BestToOthers, b.OthersToWorst)
+}
+
+// NewBestWorstCriteriaData solves the linear BWM model, the pairwise matrix is the consistent matrix of the
+// resulting weights so the criteria data can stand in for an AHP matrix.
+func NewBestWorstCriteriaData(bestWorst BestWorst) (*CriteriaData, error) {
+	if errs := bestWorst.Validate("best_worst", len(bestWorst.BestToOthers)); len(errs) > 0 {
+		return nil, errors.New(errs[0].Field + ": " + errs[0].Message)
+	}
+
+	result, err := ahp.BestWorstWeights(bestWorst.Best, bestWorst.Worst, bestWorst.BestToOthers, bestWorst.OthersToWorst)
+	if err != nil {
+		return nil, err
+	}
+
+	pairwise := ahp.RatioMatrix(result.Weights)
+	return &CriteriaData{
+		PairwiseFromJson:        pairwise,
+		PairwiseAfterCalculated: ahp.NormalizeColumns(pairwise),
+		Criteria:                result.Weights,
+		Method:                  ahp.MethodBWM,
+		ConsistencyIndex:        result.ConsistencyIndex,
+		ConsistencyRatio:        result.ConsistencyRatio,
+		IsConsistent:            result.ConsistencyRatio <= ahp.ConsistencyThreshold,
+		BestWorst:               &bestWorst,
+		Xi:                      result.Xi,
+	}, nil
+}
+
+func (b *BestWorst) Value() (driver.Value, error) {
+	if b == nil {
+		return nil, nil
+	}
+	bytes, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+func (b *BestWorst) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, b)
+	case string:
+		return json.Unmarshal([]byte(v), b)
+	case nil:
+		return nil
+	}
+	return errors.New("unsupported type for best worst")
+}
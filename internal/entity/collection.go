@@ -13,15 +13,33 @@ type CollectionEntity struct {
 	Deskripsi              string `json:"deskripsi"`
 	ScoreIsCalculated      bool   `json:"score_is_calculated"`
 	FinalScoreIsCalculated bool   `json:"final_score_is_calculated"`
+	WeightMethod           string `json:"weight_method" validate:"omitempty,weight_method" example:"fuzzy_extent"`
+	WeightIterations       int    `json:"weight_iterations"`
+	TiePolicy              string `json:"tie_policy" gorm:"size:16;default:competition" validate:"omitempty,oneof=dense competition fractional" example:"competition"`
+	ScoringMode            string `json:"scoring_mode" gorm:"size:16;default:ratings" validate:"omitempty,oneof=ratings pairwise" example:"ratings"`
+	Synthesis              string `json:"synthesis" gorm:"size:16;default:distributive" validate:"omitempty,oneof=distributive ideal topsis" example:"ideal"`
+	DecisionModel          string `json:"decision_model" gorm:"size:16;default:ahp" validate:"omitempty,oneof=ahp anp" example:"ahp"`
+}
+
+// Settings keeps the fields a client may write, the calculated flags and weight iterations are only set by the
+// server when the scores are calculated.
+func (e CollectionEntity) Settings() CollectionEntity {
+	e.ScoreIsCalculated = false
+	e.FinalScoreIsCalculated = false
+	e.WeightIterations = 0
+	return e
 }
 
 type CollectionEntityModel struct {
 	abstraction.Entity
 	CollectionEntity
-	Alternatives []AlternativeEntityModel `json:"alternatives" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE;"`
-	Scores       []ScoreEntityModel       `json:"scores" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE;"`
-	FinalScores  []FinalScoreEntityModel  `json:"final_scores" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE;"`
-	UserID       uuid.UUID                `json:"user_id" gorm:"size:191"`
+	Alternatives   []AlternativeEntityModel   `json:"alternatives" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE;"`
+	Scores         []ScoreEntityModel         `json:"scores" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE;"`
+	FinalScores    []FinalScoreEntityModel    `json:"final_scores" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE;"`
+	CriteriaMatrix *CriteriaMatrixEntityModel `json:"criteria_matrix,omitempty" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE;"`
+	UserID         uuid.UUID                  `json:"user_id" gorm:"size:191"`
+	WinnerID       *string                    `json:"winner_id" gorm:"size:191"`
+	WinnerGap      float64                    `json:"winner_gap"`
 }
 
 func (CollectionEntityModel) TableName() string {
@@ -0,0 +1,21 @@
+package entity
+
+import "testing"
+
+func TestCollectionSettingsDropsComputedFields(t *testing.T) {
+	payload := CollectionEntity{
+		Nama:                   "TPS Balige",
+		WeightMethod:           "eigenvector",
+		ScoreIsCalculated:      true,
+		FinalScoreIsCalculated: true,
+		WeightIterations:       42,
+	}
+
+	settings := payload.Settings()
+	if settings.ScoreIsCalculated || settings.FinalScoreIsCalculated || settings.WeightIterations != 0 {
+		t.Errorf("expected the computed fields to be dropped, got %+v", settings)
+	}
+	if settings.Nama != payload.Nama || settings.WeightMethod != payload.WeightMethod {
+		t.Errorf("expected the settings to be kept, got %+v", settings)
+	}
+}
@@ -1,9 +1,220 @@
 package entity
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ta13-svc/pkg/utils/ahp"
+)
+
 type CriteriaData struct {
-	PairwiseFromJson        [][]float64 `json:"pairwise"`
-	PairwiseAfterCalculated [][]float64 `json:"pairwise_after_calculated"`
-	Criteria                []float64   `json:"criteria"`
+	PairwiseFromJson        [][]float64      `json:"pairwise"`
+	PairwiseAfterCalculated [][]float64      `json:"pairwise_after_calculated"`
+	FuzzyPairwise           FuzzyMatrix      `json:"fuzzy_pairwise,omitempty"`
+	FuzzyWeights            []ahp.TFN        `json:"fuzzy_weights,omitempty"`
+	Criteria                []float64        `json:"criteria"`
+	CriteriaCodes           []string         `json:"criteria_codes"`
+	Method                  string           `json:"method"`
+	Iterations              int              `json:"iterations"`
+	LambdaMax               float64          `json:"lambda_max"`
+	ConsistencyIndex        float64          `json:"consistency_index"`
+	ConsistencyRatio        float64          `json:"consistency_ratio"`
+	IsConsistent            bool             `json:"is_consistent"`
+	Completion              string           `json:"completion,omitempty"`
+	EstimatedCells          []ahp.MatrixCell `json:"estimated_cells,omitempty"`
+
+	Scale      string                   `json:"scale,omitempty"`
+	Linguistic []ahp.LinguisticJudgment `json:"linguistic,omitempty"`
+
+	BestWorst *BestWorst `json:"best_worst,omitempty"`
+	Xi        float64    `json:"xi,omitempty"`
 }
 
 type Matrix [][]float64
+
+type FuzzyMatrix [][]ahp.TFN
+
+type IncompleteMatrix [][]*float64
+
+func NewCriteriaData(pairwise Matrix, method string) (*CriteriaData, error) {
+	if method == "" {
+		method = ahp.MethodAverage
+	}
+
+	criteriaWeights, iterations, err := ahp.Weights(pairwise, method)
+	if err != nil {
+		return nil, err
+	}
+
+	criteriaData := &CriteriaData{
+		PairwiseFromJson:        pairwise,
+		PairwiseAfterCalculated: ahp.NormalizeColumns(pairwise),
+		Criteria:                criteriaWeights,
+		Method:                  method,
+		Iterations:              iterations,
+	}
+	criteriaData.setConsistency(pairwise, criteriaWeights)
+
+	return criteriaData, nil
+}
+
+func NewFuzzyCriteriaData(pairwise FuzzyMatrix, method string) (*CriteriaData, error) {
+	if !ahp.IsFuzzyMethod(method) {
+		return NewCriteriaData(ahp.MiddleMatrix(pairwise), method)
+	}
+
+	fuzzyWeights, criteriaWeights, err := ahp.FuzzyWeights(pairwise, method)
+	if err != nil {
+		return nil, err
+	}
+
+	middle := ahp.MiddleMatrix(pairwise)
+	criteriaData := &CriteriaData{
+		PairwiseFromJson:        middle,
+		PairwiseAfterCalculated: ahp.NormalizeColumns(middle),
+		FuzzyPairwise:           pairwise,
+		FuzzyWeights:            fuzzyWeights,
+		Criteria:                criteriaWeights,
+		Method:                  method,
+	}
+	criteriaData.setConsistency(middle, criteriaWeights)
+
+	return criteriaData, nil
+}
+
+func (c *CriteriaData) setConsistency(pairwise [][]float64, weights []float64) {
+	//KONSISTENSI FUZZY DIHITUNG DARI NILAI TENGAH (m) DENGAN BOBOT CRISP
+	if ahp.IsFuzzyMethod(c.Method) {
+		weights = ahp.GeometricMeanWeights(pairwise)
+	}
+
+	//MENGHITUNG KONSISTENSI (LAMBDA MAX, CI, CR)
+	n := len(pairwise)
+	c.LambdaMax = ahp.LambdaMax(pairwise, weights)
+	c.ConsistencyIndex = ahp.ConsistencyIndex(c.LambdaMax, n)
+	c.ConsistencyRatio = ahp.ConsistencyRatio(c.ConsistencyIndex, n)
+	c.IsConsistent = ahp.IsConsistent(c.ConsistencyRatio)
+}
+
+func (c *CriteriaData) SetCriteria(criterias []CriteriaEntityModel) error {
+	if len(c.PairwiseFromJson) != len(criterias) {
+		return fmt.Errorf("pairwise matrix has %d rows but %d criteria are defined", len(c.PairwiseFromJson), len(criterias))
+	}
+
+	c.CriteriaCodes = make([]string, 0)
+	for _, criteria := range criterias {
+		c.CriteriaCodes = append(c.CriteriaCodes, criteria.Code)
+	}
+
+	return nil
+}
+
+// SetLinguistic describes the pairwise judgments as linguistic terms of the given scale.
+func (c *CriteriaData) SetLinguistic(scale string) error {
+	if scale == "" {
+		scale = ahp.ScaleSaaty
+	}
+
+	linguistic, err := ahp.Linguistic(c.PairwiseFromJson, scale)
+	if err != nil {
+		return err
+	}
+	c.Scale = scale
+	c.Linguistic = linguistic
+
+	return nil
+}
+
+// NewEqualMatrix returns the pairwise matrix of n criteria that are all equally important.
+func NewEqualMatrix(n int) Matrix {
+	m := make(Matrix, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		for j := range m[i] {
+			m[i][j] = 1
+		}
+	}
+	return m
+}
+
+func (m Matrix) IsSquare() bool {
+	if len(m) == 0 {
+		return false
+	}
+	for _, row := range m {
+		if len(row) != len(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Matrix) Value() (driver.Value, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *Matrix) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	case nil:
+		*m = nil
+		return nil
+	}
+	return errors.New("unsupported type for matrix")
+}
+
+func (m IncompleteMatrix) IsSquare() bool {
+	if len(m) == 0 {
+		return false
+	}
+	for _, row := range m {
+		if len(row) != len(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m FuzzyMatrix) IsSquare() bool {
+	if len(m) == 0 {
+		return false
+	}
+	for _, row := range m {
+		if len(row) != len(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m FuzzyMatrix) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *FuzzyMatrix) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	case nil:
+		*m = nil
+		return nil
+	}
+	return errors.New("unsupported type for fuzzy matrix")
+}
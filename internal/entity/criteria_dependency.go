@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+const (
+	DecisionModelAHP = "ahp"
+	DecisionModelANP = "anp"
+)
+
+const (
+	DependencyInner = "inner"
+	DependencyOuter = "outer"
+)
+
+// CriteriaDependencyEntity compares the criteria of a cluster, the children of a node, on how much they
+// influence the control criteria. Weight is the share of the cluster in the column of the control criteria,
+// next to the hierarchy weights which always weigh 1.
+type CriteriaDependencyEntity struct {
+	Pairwise Matrix  `json:"pairwise" gorm:"type:text"`
+	Weight   float64 `json:"weight" gorm:"default:1"`
+}
+
+type CriteriaDependencyEntityModel struct {
+	abstraction.Entity
+	CriteriaDependencyEntity
+	CollectionID string `json:"collection_id" gorm:"size:191;uniqueIndex:idx_collection_criteria_cluster"`
+	CriteriaID   string `json:"criteria_id" gorm:"size:191;uniqueIndex:idx_collection_criteria_cluster"`
+	ClusterID    string `json:"cluster_id" gorm:"size:191;uniqueIndex:idx_collection_criteria_cluster"`
+}
+
+func (CriteriaDependencyEntityModel) TableName() string {
+	return "criteria_dependencies"
+}
+
+func (m *CriteriaDependencyEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *CriteriaDependencyEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
+
+type CriteriaNetwork struct {
+	Supermatrix      Matrix    `json:"supermatrix"`
+	HierarchyWeights []float64 `json:"hierarchy_weights"`
+	Iterations       int       `json:"iterations"`
+	Cesaro           bool      `json:"cesaro"`
+}
+
+// ClusterElements returns the criteria compared in the cluster, every one of them must be a leaf criteria.
+func ClusterElements(criterias []CriteriaEntityModel, clusterID string) ([]CriteriaEntityModel, error) {
+	leaves := make(map[string]bool)
+	for _, leaf := range LeafCriterias(criterias) {
+		leaves[leaf.ID] = true
+	}
+
+	name := clusterID
+	if clusterID == GoalNodeID {
+		name = "goal"
+	}
+
+	elements := ChildCriterias(criterias, clusterID)
+	if len(elements) < 2 {
+		return nil, fmt.Errorf("cluster %s has less than 2 criteria to compare", name)
+	}
+	for _, element := range elements {
+		if !leaves[element.ID] {
+			return nil, fmt.Errorf("cluster %s contains criteria %s which is not a leaf criteria", name, element.Code)
+		}
+	}
+
+	return elements, nil
+}
+
+// DependencyKind is inner when the cluster holds the control criteria itself, outer otherwise.
+func DependencyKind(control CriteriaEntityModel, clusterID string) string {
+	if (control.ParentID == nil && clusterID == GoalNodeID) || (control.ParentID != nil && *control.ParentID == clusterID) {
+		return DependencyInner
+	}
+	return DependencyOuter
+}
+
+func (m CriteriaDependencyEntity) weight() float64 {
+	if m.Weight <= 0 {
+		return 1
+	}
+	return m.Weight
+}
+
+// ApplyNetwork replaces the hierarchy weights of the leaf criteria by the limit priorities of the weighted
+// supermatrix. The goal column holds the hierarchy weights, every leaf column holds them too together with the
+// clusters it depends on, so a leaf without dependencies keeps the hierarchy weights and no column is absorbing.
+func (w *CriteriaWeights) ApplyNetwork(criterias []CriteriaEntityModel, dependencies []CriteriaDependencyEntityModel, method string) error {
+	n := len(w.Leaves)
+	index := make(map[string]int)
+	for i, leaf := range w.Leaves {
+		index[leaf.ID] = i
+	}
+
+	//BARIS DAN KOLOM 0 ADALAH GOAL, SELANJUTNYA KRITERIA DAUN
+	supermatrix := make(Matrix, n+1)
+	for i := range supermatrix {
+		supermatrix[i] = make([]float64, n+1)
+	}
+	for i, weight := range w.Weights {
+		supermatrix[i+1][0] = weight
+	}
+
+	//MENCARI TOTAL BOBOT CLUSTER DARI SETIAP KRITERIA KONTROL, BOBOT HIRARKI SELALU 1
+	total := make([]float64, n)
+	for j := range total {
+		total[j] = 1
+	}
+	for _, dependency := range dependencies {
+		col, ok := index[dependency.CriteriaID]
+		if !ok {
+			return fmt.Errorf("dependency control criteria %s is not a leaf criteria", dependency.CriteriaID)
+		}
+		total[col] += dependency.weight()
+	}
+
+	for _, dependency := range dependencies {
+		col := index[dependency.CriteriaID]
+		elements, err := ClusterElements(criterias, dependency.ClusterID)
+		if err != nil {
+			return err
+		}
+		if len(dependency.Pairwise) != len(elements) {
+			return fmt.Errorf("dependency of criteria %s compares %d criteria but cluster %s has %d",
+				w.Leaves[col].Code, len(dependency.Pairwise), dependency.ClusterID, len(elements))
+		}
+
+		criteriaData, err := NewCriteriaData(dependency.Pairwise, method)
+		if err != nil {
+			return err
+		}
+
+		//SUPERMATRIKS TERBOBOT = PRIORITAS LOKAL x BOBOT CLUSTER
+		for k, element := range elements {
+			supermatrix[index[element.ID]+1][col+1] += criteriaData.Criteria[k] * dependency.weight() / total[col]
+		}
+	}
+	for j := range total {
+		for i, weight := range w.Weights {
+			supermatrix[i+1][j+1] += weight / total[j]
+		}
+	}
+
+	limit, err := ahp.LimitPriorities(supermatrix, 0, ahp.EigenvectorTolerance, ahp.LimitMaxIteration)
+	if err != nil {
+		return err
+	}
+
+	w.Network = &CriteriaNetwork{
+		Supermatrix:      supermatrix,
+		HierarchyWeights: w.Weights,
+		Iterations:       limit.Iterations,
+		Cesaro:           limit.Cesaro,
+	}
+
+	sum := 0.0
+	for _, p := range limit.Priorities[1:] {
+		sum += p
+	}
+	w.Weights = make([]float64, n)
+	for i, p := range limit.Priorities[1:] {
+		w.Weights[i] = p / sum
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"math"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"testing"
+)
+
+func leaf(id string, sort int) CriteriaEntityModel {
+	return CriteriaEntityModel{
+		Entity:         abstraction.Entity{ID: id},
+		CriteriaEntity: CriteriaEntity{Code: id, Sort: sort},
+	}
+}
+
+func TestApplyNetworkAfterRemovingClusterCriteria(t *testing.T) {
+	before := []CriteriaEntityModel{leaf("a", 1), leaf("b", 2), leaf("c", 3)}
+	after := before[:2]
+
+	dependency := CriteriaDependencyEntityModel{
+		CriteriaDependencyEntity: CriteriaDependencyEntity{Pairwise: Matrix{
+			{1, 2, 4},
+			{1.0 / 2, 1, 2},
+			{1.0 / 4, 1.0 / 2, 1},
+		}},
+		CriteriaID: "a",
+		ClusterID:  GoalNodeID,
+	}
+
+	weights := &CriteriaWeights{Leaves: after, Weights: []float64{0.6, 0.4}}
+	if err := weights.ApplyNetwork(after, []CriteriaDependencyEntityModel{dependency}, ahp.MethodEigenvector); err == nil {
+		t.Fatal("expected the 3x3 dependency to be rejected once c is removed")
+	}
+
+	//DEPENDENSI CLUSTER INDUK DIPETAKAN ULANG SEPERTI MATRIKS KRITERIA
+	dependency.Pairwise = CriteriaMatrixEntity{Pairwise: dependency.Pairwise}.Remap(
+		[]string{"a", "b", "c"}, []string{"a", "b"}).Pairwise
+	if err := weights.ApplyNetwork(after, []CriteriaDependencyEntityModel{dependency}, ahp.MethodEigenvector); err != nil {
+		t.Fatal(err)
+	}
+
+	//KOLOM a = (BOBOT HIRARKI + a:b = 2:1) / 2, KOLOM b = BOBOT HIRARKI
+	if weights.Network == nil || math.Abs(weights.Network.Supermatrix[1][1]-19.0/30) > 1e-9 || math.Abs(weights.Network.Supermatrix[2][2]-0.4) > 1e-9 {
+		t.Errorf("unexpected supermatrix %v", weights.Network)
+	}
+	expected := []float64{18.0 / 29, 11.0 / 29}
+	for i := range expected {
+		if math.Abs(weights.Weights[i]-expected[i]) > 1e-6 {
+			t.Errorf("expected limit weights %v, got %v", expected, weights.Weights)
+			break
+		}
+	}
+}
+
+func TestApplyNetworkKeepsTheWeightOfTheControlCriteria(t *testing.T) {
+	leaves := []CriteriaEntityModel{leaf("a", 1), leaf("b", 2), leaf("c", 3)}
+	dependency := CriteriaDependencyEntityModel{
+		CriteriaDependencyEntity: CriteriaDependencyEntity{Pairwise: Matrix{
+			{1, 2, 4},
+			{1.0 / 2, 1, 2},
+			{1.0 / 4, 1.0 / 2, 1},
+		}},
+		CriteriaID: "a",
+		ClusterID:  GoalNodeID,
+	}
+
+	weights := &CriteriaWeights{Leaves: leaves, Weights: []float64{0.5, 0.3, 0.2}}
+	if err := weights.ApplyNetwork(leaves, []CriteriaDependencyEntityModel{dependency}, ahp.MethodEigenvector); err != nil {
+		t.Fatal(err)
+	}
+
+	//KOLOM a = ((0.5, 0.3, 0.2) + (4, 2, 1)/7) / 2, LIMITNYA (14, 8, 5)/27
+	expected := []float64{14.0 / 27, 8.0 / 27, 5.0 / 27}
+	for i := range expected {
+		if math.Abs(weights.Weights[i]-expected[i]) > 1e-6 {
+			t.Errorf("expected limit weights %v, got %v", expected, weights.Weights)
+			break
+		}
+	}
+}
+
+func TestApplyNetworkWithoutDependenciesKeepsTheHierarchyWeights(t *testing.T) {
+	leaves := []CriteriaEntityModel{leaf("a", 1), leaf("b", 2), leaf("c", 3)}
+	hierarchy := []float64{0.5, 0.3, 0.2}
+
+	weights := &CriteriaWeights{Leaves: leaves, Weights: hierarchy}
+	if err := weights.ApplyNetwork(leaves, []CriteriaDependencyEntityModel{}, ahp.MethodEigenvector); err != nil {
+		t.Fatal(err)
+	}
+	for i := range hierarchy {
+		if math.Abs(weights.Weights[i]-hierarchy[i]) > 1e-9 {
+			t.Errorf("expected the hierarchy weights %v, got %v", hierarchy, weights.Weights)
+			break
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"fmt"
+	"ta13-svc/pkg/utils/ahp"
+)
+
+const GoalNodeID = ""
+
+type CriteriaNode struct {
+	ID           string          `json:"id"`
+	Code         string          `json:"code"`
+	Name         string          `json:"name"`
+	LocalWeight  float64         `json:"local_weight"`
+	GlobalWeight float64         `json:"global_weight"`
+	IsDefault    bool            `json:"is_default"`
+	Matrix       *CriteriaData   `json:"matrix,omitempty"`
+	Children     []*CriteriaNode `json:"children,omitempty"`
+}
+
+type CriteriaWeights struct {
+	Root       *CriteriaNode         `json:"root"`
+	Leaves     []CriteriaEntityModel `json:"-"`
+	Weights    []float64             `json:"weights"`
+	Codes      []string              `json:"codes"`
+	Method     string                `json:"method"`
+	Iterations int                   `json:"iterations"`
+	Network    *CriteriaNetwork      `json:"network,omitempty"`
+}
+
+// NewCriteriaWeights builds the hierarchy from the goal down, matrices are keyed by node id.
+// The goal falls back to template, other nodes without a matrix weigh their children equally.
+func NewCriteriaWeights(criterias []CriteriaEntityModel, matrices map[string]CriteriaMatrixEntity, template Matrix, method string) (*CriteriaWeights, error) {
+	if method == "" {
+		method = ahp.MethodAverage
+	}
+
+	weights := &CriteriaWeights{
+		Root:   &CriteriaNode{ID: GoalNodeID, Code: "goal", Name: "Goal", LocalWeight: 1, GlobalWeight: 1},
+		Leaves: LeafCriterias(criterias),
+		Method: method,
+	}
+
+	if err := weights.build(weights.Root, criterias, matrices, template, method, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+
+	//MENGAMBIL BOBOT GLOBAL DARI SETIAP KRITERIA DAUN
+	global := make(map[string]float64)
+	weights.Root.walk(func(node *CriteriaNode) {
+		if len(node.Children) == 0 {
+			global[node.ID] = node.GlobalWeight
+		}
+	})
+
+	weights.Weights = make([]float64, len(weights.Leaves))
+	weights.Codes = make([]string, len(weights.Leaves))
+	for i, leaf := range weights.Leaves {
+		weights.Weights[i] = global[leaf.ID]
+		weights.Codes[i] = leaf.Code
+	}
+
+	return weights, nil
+}
+
+func (w *CriteriaWeights) build(node *CriteriaNode, criterias []CriteriaEntityModel, matrices map[string]CriteriaMatrixEntity, template Matrix, method string, visited map[string]bool) error {
+	if visited[node.ID] {
+		return fmt.Errorf("criteria %s is part of a cycle in the hierarchy", node.Code)
+	}
+	visited[node.ID] = true
+
+	children := ChildCriterias(criterias, node.ID)
+	if len(children) == 0 {
+		return nil
+	}
+
+	criteriaMatrix, ok := matrices[node.ID]
+	if !ok && node.ID == GoalNodeID && len(template) == len(children) {
+		criteriaMatrix = CriteriaMatrixEntity{Pairwise: template}
+	}
+
+	var localWeights []float64
+	if criteriaMatrix.Pairwise == nil && criteriaMatrix.FuzzyPairwise == nil {
+		//TANPA MATRIKS PAIRWISE, BOBOT LOKAL DIBAGI RATA
+		node.IsDefault = true
+		localWeights = make([]float64, len(children))
+		for i := range localWeights {
+			localWeights[i] = 1 / float64(len(children))
+		}
+	} else {
+		node.IsDefault = !ok
+		criteriaData, err := criteriaMatrix.CriteriaData(method)
+		if err != nil {
+			return err
+		}
+		if err = criteriaData.SetCriteria(children); err != nil {
+			return fmt.Errorf("node %s: %s", node.Code, err.Error())
+		}
+		node.Matrix = criteriaData
+		localWeights = criteriaData.Criteria
+		w.Method = criteriaData.Method
+		w.Iterations += criteriaData.Iterations
+	}
+
+	//BOBOT GLOBAL = BOBOT LOKAL x BOBOT GLOBAL INDUK
+	for i, child := range children {
+		childNode := &CriteriaNode{
+			ID:           child.ID,
+			Code:         child.Code,
+			Name:         child.Name,
+			LocalWeight:  localWeights[i],
+			GlobalWeight: localWeights[i] * node.GlobalWeight,
+		}
+		if err := w.build(childNode, criterias, matrices, template, method, visited); err != nil {
+			return err
+		}
+		node.Children = append(node.Children, childNode)
+	}
+
+	return nil
+}
+
+func (n *CriteriaNode) walk(fn func(node *CriteriaNode)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.walk(fn)
+	}
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+type CriteriaMatrixEntity struct {
+	Pairwise      Matrix      `json:"pairwise" gorm:"type:text"`
+	FuzzyPairwise FuzzyMatrix `json:"fuzzy_pairwise,omitempty" gorm:"type:text"`
+	BestWorst     *BestWorst  `json:"best_worst,omitempty" gorm:"type:text"`
+	Scale         string      `json:"scale,omitempty" gorm:"size:16"`
+}
+
+// CriteriaMatrixEntityModel is the matrix of a node of a collection, the goal matrix without collection is the
+// default template copied into new collections.
+type CriteriaMatrixEntityModel struct {
+	abstraction.Entity
+	CriteriaMatrixEntity
+	CollectionID *string `json:"collection_id" gorm:"size:191;uniqueIndex:idx_collection_node"`
+	NodeID       string  `json:"node_id" gorm:"size:191;uniqueIndex:idx_collection_node"`
+}
+
+func (CriteriaMatrixEntityModel) TableName() string {
+	return "criteria_matrices"
+}
+
+func (m *CriteriaMatrixEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *CriteriaMatrixEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
+
+// DefaultCriteriaMatrix is the matrix of a node without stored judgments, the template when it fits the n
+// children, otherwise the children compare equally.
+func DefaultCriteriaMatrix(template CriteriaMatrixEntity, n int) CriteriaMatrixEntity {
+	if len(template.Pairwise) != n {
+		return CriteriaMatrixEntity{Pairwise: NewEqualMatrix(n)}
+	}
+	return template
+}
+
+// CriteriaData computes the local priorities, a node elicited by the best worst method keeps its BWM weights
+// whatever the method.
+func (m CriteriaMatrixEntity) CriteriaData(method string) (*CriteriaData, error) {
+	if m.BestWorst != nil {
+		return NewBestWorstCriteriaData(*m.BestWorst)
+	}
+	if m.FuzzyPairwise != nil {
+		return NewFuzzyCriteriaData(m.FuzzyPairwise, method)
+	}
+	return NewCriteriaData(m.Pairwise, method)
+}
+
+// Remap moves the judgments from the children oldIDs to the children newIDs, a new child compares equally with
+// every other child. Best worst judgments no longer fit the children and are dropped, their implied matrix stays.
+func (m CriteriaMatrixEntity) Remap(oldIDs []string, newIDs []string) CriteriaMatrixEntity {
+	index := make(map[string]int)
+	for i, id := range oldIDs {
+		index[id] = i
+	}
+
+	remapped := CriteriaMatrixEntity{Scale: m.Scale}
+	if m.Pairwise != nil {
+		remapped.Pairwise = NewEqualMatrix(len(newIDs))
+	}
+	if m.FuzzyPairwise != nil {
+		remapped.FuzzyPairwise = make(FuzzyMatrix, len(newIDs))
+		for i := range remapped.FuzzyPairwise {
+			remapped.FuzzyPairwise[i] = make([]ahp.TFN, len(newIDs))
+			for j := range remapped.FuzzyPairwise[i] {
+				remapped.FuzzyPairwise[i][j] = ahp.TFN{1, 1, 1}
+			}
+		}
+	}
+
+	for i, rowID := range newIDs {
+		for j, colID := range newIDs {
+			oi, okRow := index[rowID]
+			oj, okCol := index[colID]
+			if !okRow || !okCol {
+				continue
+			}
+			if remapped.Pairwise != nil && oi < len(m.Pairwise) && oj < len(m.Pairwise[oi]) {
+				remapped.Pairwise[i][j] = m.Pairwise[oi][oj]
+			}
+			if remapped.FuzzyPairwise != nil && oi < len(m.FuzzyPairwise) && oj < len(m.FuzzyPairwise[oi]) {
+				remapped.FuzzyPairwise[i][j] = m.FuzzyPairwise[oi][oj]
+			}
+		}
+	}
+
+	return remapped
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"encoding/json"
+	"math"
+	"ta13-svc/pkg/utils/ahp"
+	"testing"
+)
+
+func TestDefaultCriteriaMatrixWithoutStoredJudgments(t *testing.T) {
+	//NODE TANPA MATRIKS TERSIMPAN DIBOBOT RATA OLEH SETIAP METODE
+	for _, method := range ahp.WeightMethods {
+		criteriaData, err := DefaultCriteriaMatrix(CriteriaMatrixEntity{}, 3).CriteriaData(method)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		for _, weight := range criteriaData.Criteria {
+			if math.Abs(weight-1.0/3) > 1e-9 {
+				t.Errorf("%s: expected equal weights, got %v", method, criteriaData.Criteria)
+				break
+			}
+		}
+		if math.Abs(criteriaData.ConsistencyRatio) > 1e-9 || !criteriaData.IsConsistent {
+			t.Errorf("%s: expected CR 0, got %v", method, criteriaData.ConsistencyRatio)
+		}
+		if _, err = json.Marshal(criteriaData); err != nil {
+			t.Errorf("%s: %v", method, err)
+		}
+	}
+
+	template := CriteriaMatrixEntity{Pairwise: Matrix{{1, 3}, {1.0 / 3, 1}}}
+	if matrix := DefaultCriteriaMatrix(template, 2); matrix.Pairwise[0][1] != 3 {
+		t.Errorf("expected the fitting template to be kept, got %v", matrix.Pairwise)
+	}
+	if matrix := DefaultCriteriaMatrix(template, 3); matrix.Pairwise[0][1] != 1 {
+		t.Errorf("expected equal judgments when the template does not fit, got %v", matrix.Pairwise)
+	}
+}
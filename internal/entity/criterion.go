@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+const (
+	CriteriaTypeBenefit = "benefit"
+	CriteriaTypeCost    = "cost"
+)
+
+type CriteriaEntity struct {
+	Code     string  `json:"code" gorm:"size:191;uniqueIndex" validate:"required" example:"kondisi_tanah"`
+	Name     string  `json:"name" validate:"required" example:"Kondisi Tanah"`
+	Sort     int     `json:"sort" example:"8"`
+	Type     string  `json:"type" gorm:"size:16;default:benefit" validate:"omitempty,oneof=benefit cost" example:"benefit"`
+	ParentID *string `json:"parent_id" gorm:"size:191;index" example:"id of the cluster criteria, empty for the goal"`
+}
+
+type CriteriaEntityModel struct {
+	abstraction.Entity
+	CriteriaEntity
+	SubCriteriaPairwise Matrix                   `json:"sub_criteria_pairwise" gorm:"type:text"`
+	SubCriterias        []SubCriteriaEntityModel `json:"sub_criterias,omitempty" gorm:"foreignKey:CriteriaID;constraint:OnDelete:CASCADE;"`
+}
+
+func (CriteriaEntityModel) TableName() string {
+	return "criteria"
+}
+
+func (m *CriteriaEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *CriteriaEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
+
+func ChildCriterias(criterias []CriteriaEntityModel, parentID string) []CriteriaEntityModel {
+	datas := make([]CriteriaEntityModel, 0)
+	for _, criteria := range criterias {
+		if (criteria.ParentID == nil && parentID == "") || (criteria.ParentID != nil && *criteria.ParentID == parentID) {
+			datas = append(datas, criteria)
+		}
+	}
+	return datas
+}
+
+func LeafCriterias(criterias []CriteriaEntityModel) []CriteriaEntityModel {
+	parents := make(map[string]bool)
+	for _, criteria := range criterias {
+		if criteria.ParentID != nil {
+			parents[*criteria.ParentID] = true
+		}
+	}
+
+	datas := make([]CriteriaEntityModel, 0)
+	for _, criteria := range criterias {
+		if !parents[criteria.ID] {
+			datas = append(datas, criteria)
+		}
+	}
+	return datas
+}
+
+// PriorityImpacts returns the impact of every column of the alternative priority matrix. The columns hold local
+// priorities or ratings where higher is always better, a cost criteria is already reversed by its judgments, its
+// descending bins or its decreasing value function, so reversing it again would rank it backwards.
+func PriorityImpacts(criterias []CriteriaEntityModel) []string {
+	impacts := make([]string, 0)
+	for range criterias {
+		impacts = append(impacts, ahp.ImpactBenefit)
+	}
+	return impacts
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"ta13-svc/pkg/utils/ahp"
+	"testing"
+)
+
+// costPriorities rates two sites on a benefit and a cost criteria, the judgments on jarak_tpa already prefer
+// the nearer site so its local priority is the higher one.
+func costPriorities() ([]CriteriaEntityModel, Matrix, []float64) {
+	benefit := leaf("timbulan_sampah", 1)
+	benefit.Type = CriteriaTypeBenefit
+	cost := leaf("jarak_tpa", 2)
+	cost.Type = CriteriaTypeCost
+
+	priorities := Matrix{
+		{0.5, 0.8},
+		{0.5, 0.2},
+	}
+	return []CriteriaEntityModel{benefit, cost}, priorities, []float64{0.4, 0.6}
+}
+
+func TestPriorityImpactsRanksCostCriteriaByItsPriority(t *testing.T) {
+	leaves, priorities, weights := costPriorities()
+
+	results, err := ahp.Topsis(priorities, weights, PriorityImpacts(leaves))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeness := []float64{results[0].Closeness, results[1].Closeness}
+	if order := ahp.Ranking(closeness); order[0] != 0 {
+		t.Errorf("expected the site preferred on the cost criteria first, got closeness %v", closeness)
+	}
+}
+
+func TestPriorityImpactsOutranksByCostCriteriaPriority(t *testing.T) {
+	leaves, priorities, weights := costPriorities()
+
+	functions := []ahp.PreferenceFunction{{Type: ahp.PreferenceUsual}, {Type: ahp.PreferenceUsual}}
+	flows, err := ahp.PrometheeFlows(priorities, weights, PriorityImpacts(leaves), functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flows[0].Net <= flows[1].Net {
+		t.Errorf("expected the site preferred on the cost criteria to outrank, got net flows %v and %v", flows[0].Net, flows[1].Net)
+	}
+}
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+type ExpertJudgmentEntity struct {
+	Pairwise Matrix  `json:"pairwise" gorm:"type:text"`
+	Weight   float64 `json:"weight" example:"1"`
+}
+
+type ExpertJudgmentEntityModel struct {
+	abstraction.Entity
+	ExpertJudgmentEntity
+	CollectionID string `json:"collection_id" gorm:"size:191;uniqueIndex:idx_collection_user_node"`
+	UserID       string `json:"user_id" gorm:"size:191;uniqueIndex:idx_collection_user_node"`
+	NodeID       string `json:"node_id" gorm:"size:191;uniqueIndex:idx_collection_user_node"`
+}
+
+func (ExpertJudgmentEntityModel) TableName() string {
+	return "expert_judgments"
+}
+
+func (m *ExpertJudgmentEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *ExpertJudgmentEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
+
+type ExpertCriteriaData struct {
+	UserID string  `json:"user_id"`
+	Weight float64 `json:"weight"`
+	CriteriaData
+}
+
+type GroupCriteriaData struct {
+	Aggregation string               `json:"aggregation"`
+	Experts     []ExpertCriteriaData `json:"experts"`
+	Aggregated  *CriteriaData        `json:"aggregated"`
+}
+
+// NewGroupCriteriaData aggregates the expert judgments by AIJ or AIP. For AIP the aggregated
+// pairwise is the consistent matrix of the aggregated priorities and its consistency values are
+// the weighted mean of the individual ones.
+func NewGroupCriteriaData(judgments []ExpertJudgmentEntityModel, aggregation string, method string) (*GroupCriteriaData, error) {
+	if len(judgments) == 0 {
+		return nil, fmt.Errorf("no expert judgments have been submitted")
+	}
+	if aggregation == "" {
+		aggregation = ahp.AggregationAIJ
+	}
+
+	group := &GroupCriteriaData{
+		Aggregation: aggregation,
+		Experts:     make([]ExpertCriteriaData, 0),
+	}
+
+	matrices := make([][][]float64, 0)
+	priorities := make([][]float64, 0)
+	expertWeights := make([]float64, 0)
+	for _, judgment := range judgments {
+		criteriaData, err := NewCriteriaData(judgment.Pairwise, method)
+		if err != nil {
+			return nil, err
+		}
+
+		group.Experts = append(group.Experts, ExpertCriteriaData{
+			UserID:       judgment.UserID,
+			Weight:       judgment.Weight,
+			CriteriaData: *criteriaData,
+		})
+		matrices = append(matrices, judgment.Pairwise)
+		priorities = append(priorities, criteriaData.Criteria)
+		expertWeights = append(expertWeights, judgment.Weight)
+	}
+
+	switch aggregation {
+	case ahp.AggregationAIJ:
+		pairwise, err := ahp.AggregateJudgments(matrices, expertWeights)
+		if err != nil {
+			return nil, err
+		}
+
+		group.Aggregated, err = NewCriteriaData(pairwise, method)
+		if err != nil {
+			return nil, err
+		}
+	case ahp.AggregationAIP:
+		weights, err := ahp.AggregatePriorities(priorities, expertWeights)
+		if err != nil {
+			return nil, err
+		}
+
+		pairwise, err := ahp.PriorityMatrix(weights)
+		if err != nil {
+			return nil, err
+		}
+
+		group.Aggregated, err = NewCriteriaData(pairwise, method)
+		if err != nil {
+			return nil, err
+		}
+
+		//KONSISTENSI AIP = RATA RATA TERBOBOT KONSISTENSI SETIAP PAKAR
+		expertWeights, err = ahp.NormalizeExpertWeights(expertWeights)
+		if err != nil {
+			return nil, err
+		}
+		group.Aggregated.LambdaMax, group.Aggregated.ConsistencyIndex, group.Aggregated.ConsistencyRatio = 0, 0, 0
+		for i, weight := range expertWeights {
+			group.Aggregated.LambdaMax += weight * group.Experts[i].LambdaMax
+			group.Aggregated.ConsistencyIndex += weight * group.Experts[i].ConsistencyIndex
+			group.Aggregated.ConsistencyRatio += weight * group.Experts[i].ConsistencyRatio
+		}
+		group.Aggregated.IsConsistent = ahp.IsConsistent(group.Aggregated.ConsistencyRatio)
+	default:
+		return nil, fmt.Errorf("unknown aggregation %s", aggregation)
+	}
+
+	return group, nil
+}
+
+func (g *GroupCriteriaData) SetCriteria(criterias []CriteriaEntityModel) error {
+	for i := range g.Experts {
+		if err := g.Experts[i].SetCriteria(criterias); err != nil {
+			return fmt.Errorf("expert %s: %s", g.Experts[i].UserID, err.Error())
+		}
+	}
+	return g.Aggregated.SetCriteria(criterias)
+}
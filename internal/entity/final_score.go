@@ -8,8 +8,11 @@ import (
 )
 
 type FinalScoreEntity struct {
-	FinalScore float64 `json:"final_score"`
-	Rank       int8    `json:"rank"`
+	FinalScore       float64  `json:"final_score"`
+	Rank             float64  `json:"rank"`
+	DistancePositive *float64 `json:"distance_positive"`
+	DistanceNegative *float64 `json:"distance_negative"`
+	Closeness        *float64 `json:"closeness"`
 }
 
 type FinalScoreEntityModel struct {
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"fmt"
+	"ta13-svc/pkg/utils/ahp"
+)
+
+type PairwiseInput struct {
+	Pairwise      IncompleteMatrix `json:"pairwise"`
+	UpperTriangle IncompleteMatrix `json:"upper_triangle"`
+	Judgments     []ahp.Judgment   `json:"judgments"`
+
+	Linguistic []ahp.LinguisticJudgment `json:"linguistic"`
+	Scale      string                   `json:"scale" validate:"omitempty,oneof=saaty balanced geometric" example:"saaty"`
+}
+
+// Build validates whichever input was sent and returns the reciprocal matrix for n criteria, missing
+// judgments stay nil.
+func (p PairwiseInput) Build(n int) (IncompleteMatrix, []ahp.InputError) {
+	var pairwise [][]*float64
+	var errs []ahp.InputError
+
+	inputs := 0
+	for _, sent := range []bool{p.Pairwise != nil, p.UpperTriangle != nil, p.Judgments != nil, p.Linguistic != nil} {
+		if sent {
+			inputs++
+		}
+	}
+	if inputs > 1 {
+		return nil, []ahp.InputError{{Field: "pairwise", Message: "send only one of pairwise, upper_triangle, judgments or linguistic"}}
+	}
+
+	switch {
+	case p.Pairwise != nil:
+		if len(p.Pairwise) != n {
+			return nil, []ahp.InputError{{Field: "pairwise", Message: fmt.Sprintf("matrix has %d rows but %d are expected", len(p.Pairwise), n)}}
+		}
+		pairwise, errs = ahp.ValidatePairwise("pairwise", p.Pairwise)
+	case p.UpperTriangle != nil:
+		pairwise, errs = ahp.MatrixFromUpperTriangle("upper_triangle", p.UpperTriangle)
+		if len(errs) == 0 && len(pairwise) != n {
+			values := len(pairwise) * (len(pairwise) - 1) / 2
+			return nil, []ahp.InputError{{Field: "upper_triangle", Message: fmt.Sprintf("upper triangle has %d values but %d are expected", values, n*(n-1)/2)}}
+		}
+	case p.Judgments != nil:
+		pairwise, errs = ahp.MatrixFromJudgments("judgments", n, p.Judgments)
+	case p.Linguistic != nil:
+		pairwise, errs = ahp.MatrixFromLinguistic("linguistic", n, p.Linguistic, p.Scale)
+	default:
+		return nil, []ahp.InputError{{Field: "pairwise", Message: "one of pairwise, upper_triangle, judgments or linguistic is required"}}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return pairwise, nil
+}
+
+// Validate checks a complete reciprocal matrix with ahp.ValidateRatioMatrix and returns it with exact reciprocals.
+func (m Matrix) Validate(field string) (Matrix, []ahp.InputError) {
+	pairwise, errs := ahp.ValidateRatioMatrix(field, m)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return pairwise, nil
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "testing"
+
+func judged(rows ...[]float64) IncompleteMatrix {
+	matrix := make(IncompleteMatrix, len(rows))
+	for i, row := range rows {
+		matrix[i] = make([]*float64, len(row))
+		for j := range row {
+			matrix[i][j] = &row[j]
+		}
+	}
+	return matrix
+}
+
+func TestPairwiseInputBuildChecksTheShape(t *testing.T) {
+	tests := []struct {
+		name  string
+		input PairwiseInput
+		field string
+	}{
+		{"pairwise", PairwiseInput{Pairwise: judged([]float64{1, 3, 5}, []float64{1.0 / 3, 1, 2}, []float64{1.0 / 5, 1.0 / 2, 1})}, ""},
+		{"pairwise short", PairwiseInput{Pairwise: judged([]float64{1, 3}, []float64{1.0 / 3, 1})}, "pairwise"},
+		{"pairwise oversized", PairwiseInput{Pairwise: judged([]float64{1, 3, 5, 7}, []float64{1.0 / 3, 1, 2, 3}, []float64{1.0 / 5, 1.0 / 2, 1, 2}, []float64{1.0 / 7, 1.0 / 3, 1.0 / 2, 1})}, "pairwise"},
+		{"pairwise ragged", PairwiseInput{Pairwise: judged([]float64{1, 3, 5}, []float64{1.0 / 3, 1}, []float64{1.0 / 5, 1.0 / 2, 1})}, "pairwise[1]"},
+		{"upper triangle", PairwiseInput{UpperTriangle: judged([]float64{3, 5}, []float64{2})}, ""},
+		{"upper triangle short", PairwiseInput{UpperTriangle: judged([]float64{3})}, "upper_triangle"},
+		{"upper triangle oversized", PairwiseInput{UpperTriangle: judged([]float64{3, 5, 7}, []float64{2, 3}, []float64{2})}, "upper_triangle"},
+		{"upper triangle ragged", PairwiseInput{UpperTriangle: judged([]float64{3, 5}, []float64{2, 4})}, "upper_triangle[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairwise, errs := tt.input.Build(3)
+			if tt.field == "" {
+				if len(errs) > 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				if len(pairwise) != 3 || !pairwise.IsSquare() {
+					t.Errorf("expected a 3x3 matrix, got %v", pairwise)
+				}
+				return
+			}
+			if len(errs) == 0 {
+				t.Fatalf("expected an error on %s, got the matrix %v", tt.field, pairwise)
+			}
+			if errs[0].Field != tt.field {
+				t.Errorf("expected the error on %s, got %v", tt.field, errs)
+			}
+		})
+	}
+}
@@ -8,20 +8,16 @@ import (
 )
 
 type ScoreEntity struct {
-	TimbulanSampah        float64 `json:"timbulan_sampah"`
-	JarakTpa              float64 `json:"jarak_tpa"`
-	JarakPemukiman        float64 `json:"jarak_pemukiman"`
-	JarakSungai           float64 `json:"jarak_sungai"`
-	PartisipasiMasyarakat float64 `json:"partisipasi_masyarakat"`
-	CakupanRumah          float64 `json:"cakupan_rumah"`
-	Aksesibilitas         float64 `json:"aksesibilitas"`
+	Score float64 `json:"score"`
 }
 
 type ScoreEntityModel struct {
 	abstraction.Entity
 	ScoreEntity
-	AlternativeID string `json:"alternative_id" gorm:"size:191"`
-	CollectionID  string `json:"collection_id" gorm:"size:191"`
+	AlternativeID string              `json:"alternative_id" gorm:"size:191"`
+	CollectionID  string              `json:"collection_id" gorm:"size:191"`
+	CriteriaID    string              `json:"criteria_id" gorm:"size:191"`
+	Criteria      CriteriaEntityModel `json:"criteria" gorm:"foreignKey:CriteriaID;constraint:OnDelete:CASCADE;"`
 }
 
 func (ScoreEntityModel) TableName() string {
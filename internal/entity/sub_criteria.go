@@ -0,0 +1,232 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"math"
+	"sort"
+	"strings"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+type SubCriteriaEntity struct {
+	Code    string   `json:"code" gorm:"size:64" example:"perumahan"`
+	Label   string   `json:"label" validate:"required" example:"Perumahan"`
+	LabelEN string   `json:"label_en" example:"Housing"`
+	Sort    int      `json:"sort" example:"1"`
+	Min     *float64 `json:"min" example:"300"`
+	Max     *float64 `json:"max" example:"400"`
+}
+
+type SubCriteriaEntityModel struct {
+	abstraction.Entity
+	SubCriteriaEntity
+	Weight     float64 `json:"weight"`
+	CriteriaID string  `json:"criteria_id" gorm:"size:191"`
+}
+
+func (SubCriteriaEntityModel) TableName() string {
+	return "sub_criteria"
+}
+
+func (m *SubCriteriaEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *SubCriteriaEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
+
+func NewSubCriterias(criteriaID string, subCriterias []SubCriteriaEntity, pairwise Matrix, method string) ([]SubCriteriaEntityModel, *CriteriaData, error) {
+	if len(subCriterias) != len(pairwise) {
+		return nil, nil, fmt.Errorf("pairwise matrix has %d rows but %d sub criteria are given", len(pairwise), len(subCriterias))
+	}
+
+	criteriaData, err := NewCriteriaData(pairwise, method)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	datas := make([]SubCriteriaEntityModel, 0)
+	for i, subCriteria := range subCriterias {
+		datas = append(datas, SubCriteriaEntityModel{
+			Entity:            abstraction.Entity{ID: uuid.NewString()},
+			SubCriteriaEntity: subCriteria,
+			Weight:            criteriaData.Criteria[i],
+			CriteriaID:        criteriaID,
+		})
+	}
+
+	return datas, criteriaData, nil
+}
+
+// SubCriteriaFromTemplate returns the sub criteria of a template with their codes, labels and default range bins.
+func SubCriteriaFromTemplate(template ahp.SubCriteriaTemplate) []SubCriteriaEntity {
+	datas := make([]SubCriteriaEntity, 0)
+	for i, label := range template.Labels {
+		data := SubCriteriaEntity{Code: template.Codes[i], Label: label, Sort: i + 1}
+		if i < len(template.LabelsEN) {
+			data.LabelEN = template.LabelsEN[i]
+		}
+		if i < len(template.Bins) {
+			data.Min, data.Max = template.Bins[i].Min, template.Bins[i].Max
+		}
+		datas = append(datas, data)
+	}
+	return datas
+}
+
+// SubCriteriaCode derives a machine code from a label, lowercase letters and digits joined by underscores.
+func SubCriteriaCode(label string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(label) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		underscore = true
+	}
+	return b.String()
+}
+
+// KeepCodes gives a sub criteria without code the code of the stored sub criteria with its id, or else with its
+// label, so relabelling or reordering the options keeps the codes that alternatives store. ids holds the id of
+// every sub criteria, empty for a new one.
+func KeepCodes(subCriterias []SubCriteriaEntity, ids []string, stored []SubCriteriaEntityModel) error {
+	byID := make(map[string]string)
+	byLabel := make(map[string]string)
+	for _, subCriteria := range stored {
+		byID[subCriteria.ID] = subCriteria.Code
+		byLabel[subCriteria.Label] = subCriteria.Code
+	}
+
+	for i := range subCriterias {
+		if ids[i] != "" {
+			code, ok := byID[ids[i]]
+			if !ok {
+				return fmt.Errorf("sub_criterias[%d].id %s is not a sub criteria of the criteria", i, ids[i])
+			}
+			if subCriterias[i].Code == "" {
+				subCriterias[i].Code = code
+			}
+			continue
+		}
+		if subCriterias[i].Code == "" {
+			subCriterias[i].Code = byLabel[subCriterias[i].Label]
+		}
+	}
+	return nil
+}
+
+// NormalizeCodes derives the missing codes from the labels and checks that every code is a lowercase machine
+// code that is unique within the criteria.
+func NormalizeCodes(subCriterias []SubCriteriaEntity) error {
+	codes := make(map[string]bool)
+	for i := range subCriterias {
+		if subCriterias[i].Code == "" {
+			subCriterias[i].Code = SubCriteriaCode(subCriterias[i].Label)
+		}
+
+		code := subCriterias[i].Code
+		if code == "" || code != SubCriteriaCode(code) {
+			return fmt.Errorf("sub_criterias[%d].code %q must be lowercase letters and digits joined by underscores", i, code)
+		}
+		if codes[code] {
+			return fmt.Errorf("sub_criterias[%d].code %q is used more than once", i, code)
+		}
+		codes[code] = true
+	}
+	return nil
+}
+
+// HasBin tells whether raw measurements can be binned into the sub criteria.
+func (e SubCriteriaEntity) HasBin() bool {
+	return e.Min != nil || e.Max != nil
+}
+
+// Contains tells whether the raw value falls in the bin [min, max), a nil bound is open.
+func (e SubCriteriaEntity) Contains(raw float64) bool {
+	return e.HasBin() && (e.Min == nil || raw >= *e.Min) && (e.Max == nil || raw < *e.Max)
+}
+
+// ValidateBins checks that every bin has min < max and that no two bins of a criteria overlap.
+func ValidateBins(subCriterias []SubCriteriaEntity) error {
+	bins := make([]SubCriteriaEntity, 0)
+	for _, subCriteria := range subCriterias {
+		if !subCriteria.HasBin() {
+			continue
+		}
+		if subCriteria.Min != nil && subCriteria.Max != nil && *subCriteria.Min >= *subCriteria.Max {
+			return fmt.Errorf("sub criteria %s: min %v must be less than max %v", subCriteria.Label, *subCriteria.Min, *subCriteria.Max)
+		}
+		bins = append(bins, subCriteria)
+	}
+
+	lower := func(e SubCriteriaEntity) float64 {
+		if e.Min == nil {
+			return math.Inf(-1)
+		}
+		return *e.Min
+	}
+	upper := func(e SubCriteriaEntity) float64 {
+		if e.Max == nil {
+			return math.Inf(1)
+		}
+		return *e.Max
+	}
+
+	//BIN DIURUTKAN DARI BATAS BAWAH, BATAS ATAS HARUS <= BATAS BAWAH BIN BERIKUTNYA
+	sort.SliceStable(bins, func(a, b int) bool {
+		return lower(bins[a]) < lower(bins[b])
+	})
+	for i := 1; i < len(bins); i++ {
+		if upper(bins[i-1]) > lower(bins[i]) {
+			return fmt.Errorf("sub criteria %s and %s have overlapping bins", bins[i-1].Label, bins[i].Label)
+		}
+	}
+
+	return nil
+}
+
+// ResolveBin returns the code of the sub criteria whose bin holds the raw value.
+func ResolveBin(subCriterias []SubCriteriaEntityModel, raw float64) (string, error) {
+	binned := false
+	for _, subCriteria := range subCriterias {
+		if subCriteria.Contains(raw) {
+			return subCriteria.Code, nil
+		}
+		binned = binned || subCriteria.HasBin()
+	}
+	if !binned {
+		return "", fmt.Errorf("sub criteria have no range bins for raw values")
+	}
+	return "", fmt.Errorf("raw value %v falls in no sub criteria bin", raw)
+}
+
+// ValidateSubCriteriaValue checks that the value is the code of one of the sub criteria, the error lists the
+// allowed codes with their labels.
+func ValidateSubCriteriaValue(subCriterias []SubCriteriaEntityModel, value string) error {
+	options := make([]string, 0)
+	for _, subCriteria := range subCriterias {
+		if subCriteria.Code == value {
+			return nil
+		}
+		options = append(options, fmt.Sprintf("%s (%s)", subCriteria.Code, subCriteria.Label))
+	}
+	if len(options) == 0 {
+		return fmt.Errorf("criteria has no sub criteria")
+	}
+	return fmt.Errorf("%q is not a sub criteria code, allowed: %s", value, strings.Join(options, ", "))
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"testing"
+)
+
+func templateSubCriterias(template ahp.SubCriteriaTemplate) []SubCriteriaEntityModel {
+	datas := make([]SubCriteriaEntityModel, 0)
+	for _, subCriteria := range SubCriteriaFromTemplate(template) {
+		datas = append(datas, SubCriteriaEntityModel{SubCriteriaEntity: subCriteria})
+	}
+	return datas
+}
+
+func TestResolveBinEdges(t *testing.T) {
+	cases := []struct {
+		template ahp.SubCriteriaTemplate
+		raws     map[float64]string
+	}{
+		{ahp.JarakPemukimanSubCriteria(), map[float64]string{
+			0: "0_100m", 99.9: "0_100m", 100: "101_200m", 100.5: "101_200m", 200: "201_300m",
+			399.9: "301_400m", 400: "401_500m", 750: "401_500m",
+		}},
+		{ahp.PartisipasiMasyarakatSubCriteria(), map[float64]string{
+			0: "setuju_kurang_20", 19.9: "setuju_kurang_20", 20: "setuju_21_40",
+			60: "setuju_61_80", 79.9: "setuju_61_80", 80: "setuju_lebih_80",
+			100: "setuju_lebih_80",
+		}},
+		{ahp.CakupanRumahSubCriteria(), map[float64]string{
+			39: "kurang_40_rumah", 40: "41_80_rumah", 80: "81_120_rumah", 120.5: "121_160_rumah", 160: "lebih_160_rumah",
+		}},
+	}
+
+	for _, c := range cases {
+		subCriterias := templateSubCriterias(c.template)
+		if err := ValidateBins(SubCriteriaFromTemplate(c.template)); err != nil {
+			t.Fatal(err)
+		}
+		for raw, expected := range c.raws {
+			code, err := ResolveBin(subCriterias, raw)
+			if err != nil {
+				t.Fatalf("%v: %v", raw, err)
+			}
+			if code != expected {
+				t.Errorf("expected %v in %s, got %s", raw, expected, code)
+			}
+		}
+	}
+}
+
+func TestDomainCheck(t *testing.T) {
+	cases := []struct {
+		template ahp.SubCriteriaTemplate
+		raw      float64
+		valid    bool
+	}{
+		{ahp.JarakPemukimanSubCriteria(), -1, false},
+		{ahp.JarakPemukimanSubCriteria(), 0, true},
+		{ahp.JarakPemukimanSubCriteria(), 5000, true},
+		{ahp.PartisipasiMasyarakatSubCriteria(), 100, true},
+		{ahp.PartisipasiMasyarakatSubCriteria(), 100.1, false},
+		{ahp.PartisipasiMasyarakatSubCriteria(), -0.1, false},
+		{ahp.CakupanRumahSubCriteria(), -5, false},
+	}
+
+	for _, c := range cases {
+		if err := c.template.Domain.Check(c.raw); (err == nil) != c.valid {
+			t.Errorf("%v: expected valid %v, got %v", c.raw, c.valid, err)
+		}
+	}
+}
+
+func TestKeepCodes(t *testing.T) {
+	stored := []SubCriteriaEntityModel{
+		{Entity: abstraction.Entity{ID: "1"}, SubCriteriaEntity: SubCriteriaEntity{Code: "perumahan", Label: "Perumahan"}},
+		{Entity: abstraction.Entity{ID: "2"}, SubCriteriaEntity: SubCriteriaEntity{Code: "sawah", Label: "Sawah"}},
+	}
+
+	//ID MEMPERTAHANKAN KODE SAAT LABEL DIUBAH, LABEL SAMA TANPA ID JUGA
+	subCriterias := []SubCriteriaEntity{
+		{Label: "Lahan Sawah"},
+		{Label: "Perumahan"},
+		{Label: "Hutan"},
+		{Code: "kebun_baru", Label: "Kebun"},
+	}
+	if err := KeepCodes(subCriterias, []string{"2", "", "", ""}, stored); err != nil {
+		t.Fatal(err)
+	}
+	if err := NormalizeCodes(subCriterias); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"sawah", "perumahan", "hutan", "kebun_baru"}
+	for i, code := range expected {
+		if subCriterias[i].Code != code {
+			t.Errorf("sub_criterias[%d]: expected code %s, got %s", i, code, subCriterias[i].Code)
+		}
+	}
+
+	if err := KeepCodes([]SubCriteriaEntity{{Label: "Sawah"}}, []string{"9"}, stored); err == nil {
+		t.Error("expected an id of another criteria to be rejected")
+	}
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/constant"
+	"ta13-svc/pkg/utils/date"
+)
+
+type ValuePoints []ahp.ValuePoint
+
+type ValueFunctionEntity struct {
+	Type       string      `json:"type" gorm:"size:32" validate:"required,oneof=linear piecewise_linear exponential step" example:"linear"`
+	Min        float64     `json:"min" example:"0"`
+	Max        float64     `json:"max" example:"500"`
+	Decreasing bool        `json:"decreasing" example:"false"`
+	Rho        float64     `json:"rho" example:"0"`
+	Points     ValuePoints `json:"points" gorm:"type:text"`
+}
+
+type ValueFunctionEntityModel struct {
+	abstraction.Entity
+	ValueFunctionEntity
+	CollectionID string `json:"collection_id" gorm:"size:191;uniqueIndex:idx_collection_criteria"`
+	CriteriaID   string `json:"criteria_id" gorm:"size:191;uniqueIndex:idx_collection_criteria"`
+}
+
+func (ValueFunctionEntityModel) TableName() string {
+	return "value_functions"
+}
+
+func (m *ValueFunctionEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = *date.DateTodayLocal()
+	m.CreatedBy = constant.DbDefaultCreateBy
+	return
+}
+
+func (m *ValueFunctionEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.ModifiedAt = date.DateTodayLocal()
+	return
+}
+
+func (e ValueFunctionEntity) Function() ahp.ValueFunction {
+	return ahp.ValueFunction{
+		Type:       e.Type,
+		Min:        e.Min,
+		Max:        e.Max,
+		Decreasing: e.Decreasing,
+		Rho:        e.Rho,
+		Points:     e.Points,
+	}
+}
+
+func (p ValuePoints) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (p *ValuePoints) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	case nil:
+		*p = nil
+		return nil
+	}
+	return errors.New("unsupported type for value points")
+}
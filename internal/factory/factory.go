@@ -7,12 +7,20 @@ import (
 )
 
 type Factory struct {
-	Db                    *gorm.DB
-	UserRepository        repository.UserRepository
-	TpsRepository         repository.TpsRepository
-	CollectionRepository  repository.CollectionRepository
-	AlternativeRepository repository.AlternativeRepository
-	AHPRepository         repository.AhpRepository
+	Db                       *gorm.DB
+	UserRepository           repository.UserRepository
+	TpsRepository            repository.TpsRepository
+	CollectionRepository     repository.CollectionRepository
+	AlternativeRepository    repository.AlternativeRepository
+	AHPRepository            repository.AhpRepository
+	CriteriaMatrixRepository repository.CriteriaMatrixRepository
+	CriteriaRepository       repository.CriteriaRepository
+	SubCriteriaRepository    repository.SubCriteriaRepository
+	ExpertJudgmentRepository repository.ExpertJudgmentRepository
+
+	AlternativeMatrixRepository  repository.AlternativeMatrixRepository
+	CriteriaDependencyRepository repository.CriteriaDependencyRepository
+	ValueFunctionRepository      repository.ValueFunctionRepository
 }
 
 func NewFactory() *Factory {
@@ -50,4 +58,11 @@ func (f *Factory) SetupRepository() {
 	f.CollectionRepository = repository.NewCollection(f.Db)
 	f.AlternativeRepository = repository.NewAlternative(f.Db)
 	f.AHPRepository = repository.NewAHP(f.Db)
+	f.CriteriaMatrixRepository = repository.NewCriteriaMatrix(f.Db)
+	f.CriteriaRepository = repository.NewCriteria(f.Db)
+	f.SubCriteriaRepository = repository.NewSubCriteria(f.Db)
+	f.ExpertJudgmentRepository = repository.NewExpertJudgment(f.Db)
+	f.AlternativeMatrixRepository = repository.NewAlternativeMatrix(f.Db)
+	f.CriteriaDependencyRepository = repository.NewCriteriaDependency(f.Db)
+	f.ValueFunctionRepository = repository.NewValueFunction(f.Db)
 }
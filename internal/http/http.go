@@ -13,6 +13,7 @@ import (
 	"ta13-svc/internal/usecase/alternative"
 	"ta13-svc/internal/usecase/auth"
 	"ta13-svc/internal/usecase/collection"
+	"ta13-svc/internal/usecase/criteria"
 	"ta13-svc/internal/usecase/tps"
 )
 
@@ -31,4 +32,5 @@ func Init(e *echo.Echo, f *factory.Factory) {
 	collection.NewHandler(f).Route(e.Group("/collection"))
 	alternative.NewHandler(f).Route(e.Group("/alternative"))
 	ahp.NewHandler(f).Route(e.Group("/ahp"))
+	criteria.NewHandler(f).Route(e.Group("/criteria"))
 }
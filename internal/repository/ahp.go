@@ -16,6 +16,7 @@ type AhpRepository interface {
 	FindFinalScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error)
 
 	UpdateCollection(ctx context.Context, collectionID *string, e *entity.CollectionEntityModel) (*entity.CollectionEntityModel, error)
+	UpdateCollectionWinner(ctx context.Context, collectionID *string, winnerID *string, gap float64) error
 
 	DeleteAllScoreByCollection(ctx context.Context, collectionID *string) (*entity.ScoreEntityModel, error)
 	DeleteAllFinalScoreByCollection(ctx context.Context, collectionID *string) (*entity.FinalScoreEntityModel, error)
@@ -34,7 +35,7 @@ func NewAHP(db *gorm.DB) *ahp {
 }
 
 func (a *ahp) CreateScore(ctx context.Context, e []entity.ScoreEntityModel) ([]entity.ScoreEntityModel, error) {
-	err := a.Db.Create(&e).
+	err := a.Db.Omit("Criteria").Create(&e).
 		WithContext(ctx).Error
 	if err != nil {
 		return nil, err
@@ -56,7 +57,7 @@ func (a *ahp) CreateFinalScore(ctx context.Context, e []entity.FinalScoreEntityM
 func (a *ahp) FindAlternativesByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error) {
 	var datas []entity.AlternativeEntityModel
 
-	err := a.Db.Where("collection_id = ?", collectionID).Find(&datas).WithContext(ctx).Error
+	err := a.Db.Preload("Values").Where("collection_id = ?", collectionID).Order("sort asc, id asc").Find(&datas).WithContext(ctx).Error
 
 	if err != nil {
 		return datas, err
@@ -68,7 +69,7 @@ func (a *ahp) FindAlternativesByCollectionID(ctx context.Context, collectionID *
 func (a *ahp) FindScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error) {
 	var datas []entity.AlternativeEntityModel
 
-	err := a.Db.Preload("Score").Where("collection_id = ?", collectionID).Find(&datas).WithContext(ctx).Error
+	err := a.Db.Preload("Scores.Criteria").Where("collection_id = ?", collectionID).Find(&datas).WithContext(ctx).Error
 
 	if err != nil {
 		return datas, err
@@ -80,7 +81,11 @@ func (a *ahp) FindScoreByCollectionID(ctx context.Context, collectionID *string)
 func (a *ahp) FindFinalScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error) {
 	var datas []entity.AlternativeEntityModel
 
-	err := a.Db.Preload("FinalScore").Where("collection_id = ?", collectionID).Find(&datas).WithContext(ctx).Error
+	err := a.Db.Preload("FinalScore").Select("alternatives.*").
+		Joins("LEFT JOIN final_scores ON final_scores.alternative_id = alternatives.id").
+		Where("alternatives.collection_id = ?", collectionID).
+		Order("final_scores.rank IS NULL, final_scores.rank asc, alternatives.sort asc, alternatives.id asc").
+		Find(&datas).WithContext(ctx).Error
 
 	if err != nil {
 		return datas, err
@@ -100,6 +105,11 @@ func (a *ahp) UpdateCollection(ctx context.Context, collectionID *string, e *ent
 	return e, nil
 }
 
+func (a *ahp) UpdateCollectionWinner(ctx context.Context, collectionID *string, winnerID *string, gap float64) error {
+	return a.Db.Model(&entity.CollectionEntityModel{}).Where("id = ?", collectionID).
+		Updates(map[string]interface{}{"winner_id": winnerID, "winner_gap": gap}).WithContext(ctx).Error
+}
+
 func (a *ahp) DeleteAllScoreByCollection(ctx context.Context, collectionID *string) (*entity.ScoreEntityModel, error) {
 	var data *entity.ScoreEntityModel
 
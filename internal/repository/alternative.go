@@ -14,6 +14,10 @@ type AlternativeRepository interface {
 	Create(ctx context.Context, e *entity.AlternativeEntityModel) (*entity.AlternativeEntityModel, error)
 	Update(ctx context.Context, id *string, e *entity.AlternativeEntityModel) (*entity.AlternativeEntityModel, error)
 	Delete(ctx context.Context, id *string, e *entity.AlternativeEntityModel) (*entity.AlternativeEntityModel, error)
+	ReplaceValues(ctx context.Context, id *string, e []entity.AlternativeValueEntityModel) ([]entity.AlternativeValueEntityModel, error)
+	FindRawValuesByCriteriaID(ctx context.Context, criteriaID *string) ([]entity.AlternativeValueEntityModel, error)
+	FindRatedValuesByCriteriaID(ctx context.Context, criteriaID *string, codes []string) ([]entity.AlternativeValueEntityModel, error)
+	UpdateValue(ctx context.Context, id *string, value string) error
 }
 
 type alternative struct {
@@ -30,7 +34,7 @@ func NewAlternative(db *gorm.DB) *alternative {
 
 func (a *alternative) FindAll(ctx context.Context) ([]entity.AlternativeEntityModel, error) {
 	var datas []entity.AlternativeEntityModel
-	err := a.Db.Find(&datas).WithContext(ctx).Error
+	err := a.Db.Preload("Values.Criteria").Find(&datas).WithContext(ctx).Error
 	if err != nil {
 		return datas, err
 	}
@@ -40,7 +44,7 @@ func (a *alternative) FindAll(ctx context.Context) ([]entity.AlternativeEntityMo
 func (a *alternative) FindByID(ctx context.Context, id *string) (*entity.AlternativeEntityModel, error) {
 
 	var data entity.AlternativeEntityModel
-	err := a.Db.Where("id = ?", id).First(&data).
+	err := a.Db.Preload("Values.Criteria").Where("id = ?", id).First(&data).
 		WithContext(ctx).Error
 	if err != nil {
 		return nil, err
@@ -51,7 +55,7 @@ func (a *alternative) FindByID(ctx context.Context, id *string) (*entity.Alterna
 func (a *alternative) FindByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error) {
 
 	var datas []entity.AlternativeEntityModel
-	err := a.Db.Where("collection_id = ?", collectionID).Find(&datas).
+	err := a.Db.Preload("Values.Criteria").Where("collection_id = ?", collectionID).Find(&datas).
 		WithContext(ctx).Error
 	if err != nil {
 		return datas, err
@@ -61,12 +65,12 @@ func (a *alternative) FindByCollectionID(ctx context.Context, collectionID *stri
 }
 
 func (a *alternative) Create(ctx context.Context, e *entity.AlternativeEntityModel) (*entity.AlternativeEntityModel, error) {
-	err := a.Db.Create(e).
+	err := a.Db.Omit("Values").Create(e).
 		WithContext(ctx).Error
 	if err != nil {
 		return nil, err
 	}
-	err = a.Db.Model(e).First(e).
+	err = a.Db.Model(e).Omit("Values").First(e).
 		WithContext(ctx).Error
 	if err != nil {
 		return nil, err
@@ -75,21 +79,36 @@ func (a *alternative) Create(ctx context.Context, e *entity.AlternativeEntityMod
 }
 
 func (a *alternative) Update(ctx context.Context, id *string, e *entity.AlternativeEntityModel) (*entity.AlternativeEntityModel, error) {
-	err := a.Db.Model(e).Where("id = ?", id).Updates(e).
+	err := a.Db.Model(e).Omit("Values").Where("id = ?", id).Updates(e).
 		WithContext(ctx).Error
 	if err != nil {
 		return nil, err
 	}
-	err = a.Db.Model(e).Updates(e).
+	return e, nil
+}
+
+func (a *alternative) Delete(ctx context.Context, id *string, e *entity.AlternativeEntityModel) (*entity.AlternativeEntityModel, error) {
+	err := a.Db.Where("id = ?", id).Delete(e).
 		WithContext(ctx).Error
 	if err != nil {
 		return nil, err
 	}
+
 	return e, nil
 }
 
-func (a *alternative) Delete(ctx context.Context, id *string, e *entity.AlternativeEntityModel) (*entity.AlternativeEntityModel, error) {
-	err := a.Db.Where("id = ?", id).Delete(e).
+func (a *alternative) ReplaceValues(ctx context.Context, id *string, e []entity.AlternativeValueEntityModel) ([]entity.AlternativeValueEntityModel, error) {
+	err := a.Db.Where("alternative_id = ?", id).Delete(&entity.AlternativeValueEntityModel{}).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(e) == 0 {
+		return e, nil
+	}
+
+	err = a.Db.Omit("Criteria").Create(&e).
 		WithContext(ctx).Error
 	if err != nil {
 		return nil, err
@@ -97,3 +116,34 @@ func (a *alternative) Delete(ctx context.Context, id *string, e *entity.Alternat
 
 	return e, nil
 }
+
+func (a *alternative) FindRawValuesByCriteriaID(ctx context.Context, criteriaID *string) ([]entity.AlternativeValueEntityModel, error) {
+	var datas []entity.AlternativeValueEntityModel
+
+	err := a.Db.Where("criteria_id = ? AND raw IS NOT NULL", criteriaID).Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+// FindRatedValuesByCriteriaID returns the values of the criteria rated directly with one of the codes, values
+// with a raw measurement are rebinned instead.
+func (a *alternative) FindRatedValuesByCriteriaID(ctx context.Context, criteriaID *string, codes []string) ([]entity.AlternativeValueEntityModel, error) {
+	var datas []entity.AlternativeValueEntityModel
+
+	err := a.Db.Where("criteria_id = ? AND raw IS NULL AND value IN ?", criteriaID, codes).Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+func (a *alternative) UpdateValue(ctx context.Context, id *string, value string) error {
+	return a.Db.Model(&entity.AlternativeValueEntityModel{}).Where("id = ?", id).Update("value", value).
+		WithContext(ctx).Error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+)
+
+type AlternativeMatrixRepository interface {
+	FindByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeMatrixEntityModel, error)
+
+	Upsert(ctx context.Context, e *entity.AlternativeMatrixEntityModel) (*entity.AlternativeMatrixEntityModel, error)
+	Update(ctx context.Context, id *string, e *entity.AlternativeMatrixEntityModel) (*entity.AlternativeMatrixEntityModel, error)
+	DeleteByCriteriaID(ctx context.Context, criteriaID *string) error
+}
+
+type alternativeMatrix struct {
+	abstraction.Repository
+}
+
+func NewAlternativeMatrix(db *gorm.DB) *alternativeMatrix {
+	return &alternativeMatrix{
+		abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (a *alternativeMatrix) FindByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeMatrixEntityModel, error) {
+	var datas []entity.AlternativeMatrixEntityModel
+
+	err := a.Db.Where("collection_id = ?", collectionID).Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+func (a *alternativeMatrix) Upsert(ctx context.Context, e *entity.AlternativeMatrixEntityModel) (*entity.AlternativeMatrixEntityModel, error) {
+	var data entity.AlternativeMatrixEntityModel
+
+	err := a.Db.Where("collection_id = ? AND criteria_id = ?", e.CollectionID, e.CriteriaID).First(&data).
+		WithContext(ctx).Error
+	if err == gorm.ErrRecordNotFound {
+		err = a.Db.Create(e).
+			WithContext(ctx).Error
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.ID = data.ID
+	err = a.Db.Model(e).Where("id = ?", data.ID).Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (a *alternativeMatrix) Update(ctx context.Context, id *string, e *entity.AlternativeMatrixEntityModel) (*entity.AlternativeMatrixEntityModel, error) {
+	err := a.Db.Model(e).Where("id = ?", id).Select("pairwise", "alternative_ids", "modified_at").Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (a *alternativeMatrix) DeleteByCriteriaID(ctx context.Context, criteriaID *string) error {
+	return a.Db.Where("criteria_id = ?", criteriaID).Delete(&entity.AlternativeMatrixEntityModel{}).
+		WithContext(ctx).Error
+}
@@ -15,6 +15,7 @@ type CollectionRepository interface {
 	Create(ctx context.Context, e *entity.CollectionEntityModel) (*entity.CollectionEntityModel, error)
 	Update(ctx context.Context, id *string, e *entity.CollectionEntityModel) (*entity.CollectionEntityModel, error)
 	Delete(ctx context.Context, id *string, e *entity.CollectionEntityModel) (*entity.CollectionEntityModel, error)
+	ResetCalculated(ctx context.Context, id *string) error
 }
 
 type collection struct {
@@ -119,3 +120,14 @@ func (c *collection) Delete(ctx context.Context, id *string, e *entity.Collectio
 
 	return e, nil
 }
+
+func (c *collection) ResetCalculated(ctx context.Context, id *string) error {
+	err := c.Db.Model(&entity.CollectionEntityModel{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"score_is_calculated": false, "final_score_is_calculated": false}).
+		WithContext(ctx).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+)
+
+type CriteriaRepository interface {
+	FindAll(ctx context.Context) ([]entity.CriteriaEntityModel, error)
+	FindByID(ctx context.Context, id *string) (*entity.CriteriaEntityModel, error)
+	Create(ctx context.Context, e *entity.CriteriaEntityModel) (*entity.CriteriaEntityModel, error)
+	Update(ctx context.Context, id *string, e *entity.CriteriaEntityModel) (*entity.CriteriaEntityModel, error)
+	Delete(ctx context.Context, id *string, e *entity.CriteriaEntityModel) (*entity.CriteriaEntityModel, error)
+	UpdateParent(ctx context.Context, id *string, parentID *string) error
+	ReplaceParent(ctx context.Context, parentID *string, newParentID *string) error
+}
+
+type criteria struct {
+	abstraction.Repository
+}
+
+func NewCriteria(db *gorm.DB) *criteria {
+	return &criteria{
+		abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (c *criteria) FindAll(ctx context.Context) ([]entity.CriteriaEntityModel, error) {
+	var datas []entity.CriteriaEntityModel
+
+	err := c.Db.Order("sort asc, id asc").Find(&datas).WithContext(ctx).Error
+
+	if err != nil {
+		return datas, err
+	}
+	return datas, nil
+}
+
+func (c *criteria) FindByID(ctx context.Context, id *string) (*entity.CriteriaEntityModel, error) {
+	var data entity.CriteriaEntityModel
+
+	err := c.Db.Where("id = ?", id).First(&data).
+		WithContext(ctx).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (c *criteria) Create(ctx context.Context, e *entity.CriteriaEntityModel) (*entity.CriteriaEntityModel, error) {
+	err := c.Db.Create(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteria) Update(ctx context.Context, id *string, e *entity.CriteriaEntityModel) (*entity.CriteriaEntityModel, error) {
+	err := c.Db.Model(e).Where("id = ?", id).Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteria) Delete(ctx context.Context, id *string, e *entity.CriteriaEntityModel) (*entity.CriteriaEntityModel, error) {
+	err := c.Db.Where("id = ?", id).Delete(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteria) UpdateParent(ctx context.Context, id *string, parentID *string) error {
+	return c.Db.Model(&entity.CriteriaEntityModel{}).Where("id = ?", id).Update("parent_id", parentID).
+		WithContext(ctx).Error
+}
+
+func (c *criteria) ReplaceParent(ctx context.Context, parentID *string, newParentID *string) error {
+	return c.Db.Model(&entity.CriteriaEntityModel{}).Where("parent_id = ?", parentID).Update("parent_id", newParentID).
+		WithContext(ctx).Error
+}
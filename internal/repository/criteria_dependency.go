@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+)
+
+type CriteriaDependencyRepository interface {
+	FindAll(ctx context.Context) ([]entity.CriteriaDependencyEntityModel, error)
+	FindByCollectionID(ctx context.Context, collectionID *string) ([]entity.CriteriaDependencyEntityModel, error)
+
+	Upsert(ctx context.Context, e *entity.CriteriaDependencyEntityModel) (*entity.CriteriaDependencyEntityModel, error)
+	Update(ctx context.Context, id *string, e *entity.CriteriaDependencyEntityModel) (*entity.CriteriaDependencyEntityModel, error)
+	Delete(ctx context.Context, collectionID *string, criteriaID *string, clusterID *string) error
+	DeleteByID(ctx context.Context, id *string) error
+	DeleteByCriteriaID(ctx context.Context, criteriaID *string) error
+}
+
+type criteriaDependency struct {
+	abstraction.Repository
+}
+
+func NewCriteriaDependency(db *gorm.DB) *criteriaDependency {
+	return &criteriaDependency{
+		abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (c *criteriaDependency) FindAll(ctx context.Context) ([]entity.CriteriaDependencyEntityModel, error) {
+	var datas []entity.CriteriaDependencyEntityModel
+
+	err := c.Db.Order("collection_id, criteria_id, cluster_id").Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+func (c *criteriaDependency) FindByCollectionID(ctx context.Context, collectionID *string) ([]entity.CriteriaDependencyEntityModel, error) {
+	var datas []entity.CriteriaDependencyEntityModel
+
+	err := c.Db.Where("collection_id = ?", collectionID).Order("criteria_id, cluster_id").Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+func (c *criteriaDependency) Upsert(ctx context.Context, e *entity.CriteriaDependencyEntityModel) (*entity.CriteriaDependencyEntityModel, error) {
+	var data entity.CriteriaDependencyEntityModel
+
+	err := c.Db.Where("collection_id = ? AND criteria_id = ? AND cluster_id = ?", e.CollectionID, e.CriteriaID, e.ClusterID).First(&data).
+		WithContext(ctx).Error
+	if err == gorm.ErrRecordNotFound {
+		err = c.Db.Create(e).
+			WithContext(ctx).Error
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.ID = data.ID
+	err = c.Db.Model(e).Where("id = ?", data.ID).Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteriaDependency) Update(ctx context.Context, id *string, e *entity.CriteriaDependencyEntityModel) (*entity.CriteriaDependencyEntityModel, error) {
+	err := c.Db.Model(e).Where("id = ?", id).Select("pairwise", "modified_at").Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteriaDependency) Delete(ctx context.Context, collectionID *string, criteriaID *string, clusterID *string) error {
+	return c.Db.Where("collection_id = ? AND criteria_id = ? AND cluster_id = ?", collectionID, criteriaID, clusterID).
+		Delete(&entity.CriteriaDependencyEntityModel{}).
+		WithContext(ctx).Error
+}
+
+func (c *criteriaDependency) DeleteByID(ctx context.Context, id *string) error {
+	return c.Db.Where("id = ?", id).Delete(&entity.CriteriaDependencyEntityModel{}).
+		WithContext(ctx).Error
+}
+
+// DeleteByCriteriaID removes the dependencies controlled by the criteria and those comparing its children.
+func (c *criteriaDependency) DeleteByCriteriaID(ctx context.Context, criteriaID *string) error {
+	return c.Db.Where("criteria_id = ? OR cluster_id = ?", criteriaID, criteriaID).Delete(&entity.CriteriaDependencyEntityModel{}).
+		WithContext(ctx).Error
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+)
+
+type CriteriaMatrixRepository interface {
+	FindDefault(ctx context.Context) (*entity.CriteriaMatrixEntityModel, error)
+	FindByCollectionID(ctx context.Context, collectionID *string) (*entity.CriteriaMatrixEntityModel, error)
+	FindByCollectionNodeID(ctx context.Context, collectionID *string, nodeID string) (*entity.CriteriaMatrixEntityModel, error)
+	FindAllByCollectionID(ctx context.Context, collectionID *string) ([]entity.CriteriaMatrixEntityModel, error)
+	FindAllByNodeID(ctx context.Context, nodeID *string) ([]entity.CriteriaMatrixEntityModel, error)
+
+	UpdateDefault(ctx context.Context, e *entity.CriteriaMatrixEntityModel) (*entity.CriteriaMatrixEntityModel, error)
+	Upsert(ctx context.Context, e *entity.CriteriaMatrixEntityModel) (*entity.CriteriaMatrixEntityModel, error)
+	Update(ctx context.Context, id *string, e *entity.CriteriaMatrixEntityModel) (*entity.CriteriaMatrixEntityModel, error)
+	DeleteByNodeID(ctx context.Context, nodeID *string) error
+}
+
+// criteriaMatrixColumns are always written on update, Updates with a struct skips zero values and would keep
+// a fuzzy_pairwise, best_worst or scale that the new judgments no longer have.
+var criteriaMatrixColumns = []string{"pairwise", "fuzzy_pairwise", "best_worst", "scale", "modified_at"}
+
+type criteriaMatrix struct {
+	abstraction.Repository
+}
+
+func NewCriteriaMatrix(db *gorm.DB) *criteriaMatrix {
+	return &criteriaMatrix{
+		abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+// FindDefault returns the template of the goal, the matrix without collection.
+func (c *criteriaMatrix) FindDefault(ctx context.Context) (*entity.CriteriaMatrixEntityModel, error) {
+	var data entity.CriteriaMatrixEntityModel
+
+	err := c.Db.Where("collection_id IS NULL AND node_id = ?", entity.GoalNodeID).First(&data).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (c *criteriaMatrix) FindByCollectionID(ctx context.Context, collectionID *string) (*entity.CriteriaMatrixEntityModel, error) {
+	return c.FindByCollectionNodeID(ctx, collectionID, entity.GoalNodeID)
+}
+
+func (c *criteriaMatrix) FindByCollectionNodeID(ctx context.Context, collectionID *string, nodeID string) (*entity.CriteriaMatrixEntityModel, error) {
+	var data entity.CriteriaMatrixEntityModel
+
+	err := c.Db.Where("collection_id = ? AND node_id = ?", collectionID, nodeID).First(&data).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (c *criteriaMatrix) FindAllByCollectionID(ctx context.Context, collectionID *string) ([]entity.CriteriaMatrixEntityModel, error) {
+	var datas []entity.CriteriaMatrixEntityModel
+
+	err := c.Db.Where("collection_id = ?", collectionID).Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+// FindAllByNodeID returns the matrices of the node in every collection and the default template.
+func (c *criteriaMatrix) FindAllByNodeID(ctx context.Context, nodeID *string) ([]entity.CriteriaMatrixEntityModel, error) {
+	var datas []entity.CriteriaMatrixEntityModel
+
+	err := c.Db.Where("node_id = ?", nodeID).Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+func (c *criteriaMatrix) UpdateDefault(ctx context.Context, e *entity.CriteriaMatrixEntityModel) (*entity.CriteriaMatrixEntityModel, error) {
+	e.CollectionID = nil
+	e.NodeID = entity.GoalNodeID
+
+	data, err := c.FindDefault(ctx)
+	if err == gorm.ErrRecordNotFound {
+		err = c.Db.Create(e).
+			WithContext(ctx).Error
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.ID = data.ID
+	err = c.Db.Model(e).Where("id = ?", data.ID).Select(criteriaMatrixColumns).Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteriaMatrix) Upsert(ctx context.Context, e *entity.CriteriaMatrixEntityModel) (*entity.CriteriaMatrixEntityModel, error) {
+	var data entity.CriteriaMatrixEntityModel
+
+	err := c.Db.Where("collection_id = ? AND node_id = ?", e.CollectionID, e.NodeID).First(&data).
+		WithContext(ctx).Error
+	if err == gorm.ErrRecordNotFound {
+		err = c.Db.Create(e).
+			WithContext(ctx).Error
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	//KOLOM MATRIKS DISEBUT EKSPLISIT AGAR BENTUK INPUT SEBELUMNYA (FUZZY/BWM) IKUT TERHAPUS
+	e.ID = data.ID
+	err = c.Db.Model(e).Where("id = ?", data.ID).Select(criteriaMatrixColumns).Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteriaMatrix) Update(ctx context.Context, id *string, e *entity.CriteriaMatrixEntityModel) (*entity.CriteriaMatrixEntityModel, error) {
+	err := c.Db.Model(e).Where("id = ?", id).Select(criteriaMatrixColumns).Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *criteriaMatrix) DeleteByNodeID(ctx context.Context, nodeID *string) error {
+	return c.Db.Where("node_id = ?", nodeID).Delete(&entity.CriteriaMatrixEntityModel{}).
+		WithContext(ctx).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+)
+
+type ExpertJudgmentRepository interface {
+	FindByCollectionNodeID(ctx context.Context, collectionID *string, nodeID string) ([]entity.ExpertJudgmentEntityModel, error)
+
+	Upsert(ctx context.Context, e *entity.ExpertJudgmentEntityModel) (*entity.ExpertJudgmentEntityModel, error)
+	Delete(ctx context.Context, collectionID *string, userID *string, nodeID string) error
+}
+
+type expertJudgment struct {
+	abstraction.Repository
+}
+
+func NewExpertJudgment(db *gorm.DB) *expertJudgment {
+	return &expertJudgment{
+		abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (e *expertJudgment) FindByCollectionNodeID(ctx context.Context, collectionID *string, nodeID string) ([]entity.ExpertJudgmentEntityModel, error) {
+	var datas []entity.ExpertJudgmentEntityModel
+
+	err := e.Db.Where("collection_id = ? AND node_id = ?", collectionID, nodeID).Order("created_at asc, id asc").Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+func (e *expertJudgment) Upsert(ctx context.Context, m *entity.ExpertJudgmentEntityModel) (*entity.ExpertJudgmentEntityModel, error) {
+	var data entity.ExpertJudgmentEntityModel
+
+	err := e.Db.Where("collection_id = ? AND user_id = ? AND node_id = ?", m.CollectionID, m.UserID, m.NodeID).First(&data).
+		WithContext(ctx).Error
+	if err == gorm.ErrRecordNotFound {
+		err = e.Db.Create(m).
+			WithContext(ctx).Error
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.ID = data.ID
+	err = e.Db.Model(m).Where("id = ?", data.ID).Updates(m).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (e *expertJudgment) Delete(ctx context.Context, collectionID *string, userID *string, nodeID string) error {
+	result := e.Db.Where("collection_id = ? AND user_id = ? AND node_id = ?", collectionID, userID, nodeID).
+		Delete(&entity.ExpertJudgmentEntityModel{}).WithContext(ctx)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
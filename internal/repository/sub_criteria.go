@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+)
+
+type SubCriteriaRepository interface {
+	FindAll(ctx context.Context) ([]entity.SubCriteriaEntityModel, error)
+	FindByCriteriaID(ctx context.Context, criteriaID *string) ([]entity.SubCriteriaEntityModel, error)
+	ReplaceByCriteriaID(ctx context.Context, criteriaID *string, e []entity.SubCriteriaEntityModel) ([]entity.SubCriteriaEntityModel, error)
+}
+
+type subCriteria struct {
+	abstraction.Repository
+}
+
+func NewSubCriteria(db *gorm.DB) *subCriteria {
+	return &subCriteria{
+		abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (s *subCriteria) FindAll(ctx context.Context) ([]entity.SubCriteriaEntityModel, error) {
+	var datas []entity.SubCriteriaEntityModel
+
+	err := s.Db.Order("sort asc, id asc").Find(&datas).WithContext(ctx).Error
+
+	if err != nil {
+		return datas, err
+	}
+	return datas, nil
+}
+
+func (s *subCriteria) FindByCriteriaID(ctx context.Context, criteriaID *string) ([]entity.SubCriteriaEntityModel, error) {
+	var datas []entity.SubCriteriaEntityModel
+
+	err := s.Db.Where("criteria_id = ?", criteriaID).Order("sort asc, id asc").Find(&datas).
+		WithContext(ctx).Error
+
+	if err != nil {
+		return datas, err
+	}
+	return datas, nil
+}
+
+func (s *subCriteria) ReplaceByCriteriaID(ctx context.Context, criteriaID *string, e []entity.SubCriteriaEntityModel) ([]entity.SubCriteriaEntityModel, error) {
+	err := s.Db.Where("criteria_id = ?", criteriaID).Delete(&entity.SubCriteriaEntityModel{}).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(e) == 0 {
+		return e, nil
+	}
+
+	err = s.Db.Create(&e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
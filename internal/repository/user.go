@@ -9,6 +9,7 @@ import (
 
 type UserRepository interface {
 	FindByUsername(ctx context.Context, username *string) (*entity.UserEntityModel, error)
+	FindByID(ctx context.Context, id *string) (*entity.UserEntityModel, error)
 	Create(ctx context.Context, e *entity.UserEntity) (*entity.UserEntityModel, error)
 }
 
@@ -36,6 +37,18 @@ func (u user) FindByUsername(ctx context.Context, username *string) (*entity.Use
 	return &data, nil
 }
 
+func (u user) FindByID(ctx context.Context, id *string) (*entity.UserEntityModel, error) {
+	var data entity.UserEntityModel
+
+	err := u.Db.Where("id = ?", id).First(&data).WithContext(ctx).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
 func (u user) Create(ctx context.Context, e *entity.UserEntity) (*entity.UserEntityModel, error) {
 	var data entity.UserEntityModel
 	data.Entity.BeforeCreate(u.Db)
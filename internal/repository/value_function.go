@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"ta13-svc/internal/abstraction"
+	"ta13-svc/internal/entity"
+)
+
+type ValueFunctionRepository interface {
+	FindByCollectionID(ctx context.Context, collectionID *string) ([]entity.ValueFunctionEntityModel, error)
+
+	Upsert(ctx context.Context, e *entity.ValueFunctionEntityModel) (*entity.ValueFunctionEntityModel, error)
+	Delete(ctx context.Context, collectionID *string, criteriaID *string) error
+	DeleteByCriteriaID(ctx context.Context, criteriaID *string) error
+}
+
+type valueFunction struct {
+	abstraction.Repository
+}
+
+func NewValueFunction(db *gorm.DB) *valueFunction {
+	return &valueFunction{
+		abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (v *valueFunction) FindByCollectionID(ctx context.Context, collectionID *string) ([]entity.ValueFunctionEntityModel, error) {
+	var datas []entity.ValueFunctionEntityModel
+
+	err := v.Db.Where("collection_id = ?", collectionID).Find(&datas).
+		WithContext(ctx).Error
+	if err != nil {
+		return datas, err
+	}
+
+	return datas, nil
+}
+
+func (v *valueFunction) Upsert(ctx context.Context, e *entity.ValueFunctionEntityModel) (*entity.ValueFunctionEntityModel, error) {
+	var data entity.ValueFunctionEntityModel
+
+	err := v.Db.Where("collection_id = ? AND criteria_id = ?", e.CollectionID, e.CriteriaID).First(&data).
+		WithContext(ctx).Error
+	if err == gorm.ErrRecordNotFound {
+		err = v.Db.Create(e).
+			WithContext(ctx).Error
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	//SEMUA KOLOM FUNGSI DITULIS, NILAI NOL (DECREASING FALSE, RHO 0) JUGA BERLAKU
+	e.ID = data.ID
+	err = v.Db.Model(e).Where("id = ?", data.ID).Select("type", "min", "max", "decreasing", "rho", "points", "modified_at").Updates(e).
+		WithContext(ctx).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (v *valueFunction) Delete(ctx context.Context, collectionID *string, criteriaID *string) error {
+	return v.Db.Where("collection_id = ? AND criteria_id = ?", collectionID, criteriaID).Delete(&entity.ValueFunctionEntityModel{}).
+		WithContext(ctx).Error
+}
+
+func (v *valueFunction) DeleteByCriteriaID(ctx context.Context, criteriaID *string) error {
+	return v.Db.Where("criteria_id = ?", criteriaID).Delete(&entity.ValueFunctionEntityModel{}).
+		WithContext(ctx).Error
+}
@@ -20,10 +20,12 @@ func NewHandler(f *factory.Factory) *handler {
 
 // GetCriteria
 // @Summary Get All Criteria Alternative
-// @Description Get ALl Criteria Alternative
+// @Description Get ALl Criteria Alternative with lambda max, consistency index, consistency ratio and the judgments as linguistic terms of the requested scale, a best worst template returns its BWM weights, ξ and consistency ratio
 // @Tags AHP
 // @Accept json
 // @Produce json
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param scale query string false "linguistic scale, defaults to the scale the matrix was judged on" Enums(saaty, balanced, geometric)
 // @Failure 400 {object} response.errorResponse
 // @Failure 404 {object} response.errorResponse
 // @Failure 500 {object} response.errorResponse
@@ -31,7 +33,101 @@ func NewHandler(f *factory.Factory) *handler {
 func (h *handler) GetCriteria(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	result, err := h.service.FindCriteriaAlternative(ctx)
+	payload := new(dto.CriteriaGetRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindCriteriaAlternative(ctx, payload.Method, payload.Scale)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetCriteriaDiagnosis
+// @Summary Get Criteria Alternative Inconsistency Diagnosis
+// @Description Rank every judgment of the default criteria matrix by its deviation from w_i/w_j with a suggested value and the resulting consistency ratio, auto_repair applies the suggestions until the consistency ratio is at most 0.1 without saving
+// @Tags AHP
+// @Accept json
+// @Produce json
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param auto_repair query bool false "apply the suggestions until the matrix is consistent"
+// @Success 200 {object} dto.CriteriaDiagnosisResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /ahp/criteria/diagnosis [get]
+func (h *handler) GetCriteriaDiagnosis(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CriteriaDiagnosisRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindCriteriaDiagnosis(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetHierarchy
+// @Summary Get Criteria Hierarchy By Collection ID
+// @Description Get the criteria hierarchy of a collection with local and global priorities per node, in the anp decision model the weights are the limit priorities of the weighted supermatrix
+// @Tags AHP
+// @Accept json
+// @Produce json
+// @Param collection_id path string true "collection_id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /ahp/hierarchy/{collection_id} [get]
+func (h *handler) GetHierarchy(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.AHPCalculateRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindHierarchyByCollectionID(ctx, &payload.CollectionID, payload.Method)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetSubCriteriaCatalog
+// @Summary Get Sub Criteria Catalog
+// @Description Get every leaf criteria with its sub criteria options, the code of an option is the value sent and stored for an alternative, labels are given in indonesian (id) and english (en)
+// @Tags AHP
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.SubCriteriaCatalogResponseDoc
+// @Failure 500 {object} response.errorResponse
+// @Router /ahp/subcriteria [get]
+func (h *handler) GetSubCriteriaCatalog(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	result, err := h.service.FindSubCriteriaCatalog(ctx)
 	if err != nil {
 		return response.ErrorResponse(err).Send(c)
 	}
@@ -72,7 +168,7 @@ func (h *handler) GetScores(c echo.Context) error {
 
 // GetFinalScores
 // @Summary Get Final Scores
-// @Description Get Final Scores
+// @Description Get Final Scores ordered by rank
 // @Tags AHP
 // @Accept json
 // @Produce json
@@ -103,7 +199,7 @@ func (h *handler) GetFinalScores(c echo.Context) error {
 
 // UpdateCriteriaAlternative
 // @Summary Update Criteria Alternative
-// @Description Update Criteria Alternative
+// @Description Update the default Criteria Alternative template used by collections without their own matrix, given as a full pairwise matrix, an upper_triangle or a list of judgments on the 1/9..9 scale, or as linguistic terms (sama penting .. mutlak lebih penting) valued on the saaty, balanced or geometric scale, or by the best worst method (best_worst) whose judgments are stored with the consistent matrix of their weights, with reciprocals derived automatically, null cells are estimated by harker or llsm, rejected when the comparisons are not connected or when the consistency ratio exceeds 0.1 unless force is set
 // @Tags AHP
 // @Accept json
 // @Produce json
@@ -133,11 +229,12 @@ func (h *handler) UpdateCriteriaAlternative(c echo.Context) error {
 
 // CalculateAlternativeToPoint
 // @Summary Calculate Alternative to Point
-// @Description Calculate Alternative to Point
+// @Description Calculate Alternative to Point, with ideal synthesis every criteria is divided by its best sub criteria intensity in the ratings mode and by its best alternative in the pairwise mode. A criteria with a value function of the collection scores the raw value of the alternative instead of its sub criteria. Alternatives with values outside the sub criteria catalog are listed and the calculation is refused
 // @Tags AHP
 // @Accept json
 // @Produce json
 // @Param collection_id path string true "collection_id path"
+// @Param synthesis query string false "synthesis mode, defaults to the one of the collection" Enums(distributive, ideal, topsis)
 // @Failure 400 {object} response.errorResponse
 // @Failure 404 {object} response.errorResponse
 // @Failure 500 {object} response.errorResponse
@@ -145,7 +242,7 @@ func (h *handler) UpdateCriteriaAlternative(c echo.Context) error {
 func (h *handler) CalculateAlternativeToPoint(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	payload := new(dto.AHPByCollectionIDRequest)
+	payload := new(dto.AHPPointCalculateRequest)
 	if err = c.Bind(payload); err != nil {
 		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
 	}
@@ -154,7 +251,7 @@ func (h *handler) CalculateAlternativeToPoint(c echo.Context) error {
 		return response.Send(c)
 	}
 
-	result, err := h.service.CalculateAlternativeToPoint(ctx, &payload.CollectionID)
+	result, err := h.service.CalculateAlternativeToPoint(ctx, &payload.CollectionID, payload.Synthesis)
 	if err != nil {
 		return response.ErrorResponse(err).Send(c)
 	}
@@ -170,6 +267,8 @@ func (h *handler) CalculateAlternativeToPoint(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param collection_id path string true "collection_id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param synthesis query string false "synthesis mode, defaults to the one of the collection" Enums(distributive, ideal, topsis)
 // @Failure 400 {object} response.errorResponse
 // @Failure 404 {object} response.errorResponse
 // @Failure 500 {object} response.errorResponse
@@ -177,7 +276,7 @@ func (h *handler) CalculateAlternativeToPoint(c echo.Context) error {
 func (h *handler) CalculateScores(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	payload := new(dto.AHPByCollectionIDRequest)
+	payload := new(dto.AHPScoreCalculateRequest)
 	if err = c.Bind(payload); err != nil {
 		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
 	}
@@ -186,7 +285,7 @@ func (h *handler) CalculateScores(c echo.Context) error {
 		return response.Send(c)
 	}
 
-	result, err := h.service.CalculateScoreAlternativeByCollectionID(ctx, &payload.CollectionID)
+	result, err := h.service.CalculateScoreAlternativeByCollectionID(ctx, &payload.CollectionID, payload.Method, payload.Synthesis)
 	if err != nil {
 		return response.ErrorResponse(err).Send(c)
 	}
@@ -196,11 +295,14 @@ func (h *handler) CalculateScores(c echo.Context) error {
 
 // CalculateFinalScores
 // @Summary Calculate Final Scores by Collection ID
-// @Description Calculate Final Scores by Collection ID
+// @Description Calculate Final Scores by Collection ID, with topsis synthesis the final score is the closeness coefficient times 100 and the distances to the positive and negative ideal are stored alongside, every column is a priority where higher is better so a cost criteria is reversed by its ratings
 // @Tags AHP
 // @Accept json
 // @Produce json
 // @Param collection_id path string true "collection_id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param synthesis query string false "synthesis mode, defaults to the one of the collection" Enums(distributive, ideal, topsis)
+// @Param tie_policy query string false "rank of equal final scores" Enums(dense, competition, fractional)
 // @Failure 400 {object} response.errorResponse
 // @Failure 404 {object} response.errorResponse
 // @Failure 500 {object} response.errorResponse
@@ -208,7 +310,150 @@ func (h *handler) CalculateScores(c echo.Context) error {
 func (h *handler) CalculateFinalScores(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	payload := new(dto.AHPByCollectionIDRequest)
+	payload := new(dto.AHPFinalScoreCalculateRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.CalculateFinalScoreByCollectionID(ctx, &payload.CollectionID, payload.Method, payload.Synthesis, payload.TiePolicy)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// CalculateSensitivity
+// @Summary Calculate Sensitivity by Collection ID
+// @Description Sweep every criteria weight while rescaling the others proportionally and report the ranking per step and every weight between min and max at which two alternatives swap places (rank is the place they swap at, 1 for the top), nothing is stored
+// @Tags AHP
+// @Accept json
+// @Produce json
+// @Param collection_id path string true "collection_id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param steps query int false "number of steps, default 10"
+// @Param min query number false "lowest swept weight, default 0"
+// @Param max query number false "highest swept weight, default 1"
+// @Success 200 {object} dto.SensitivityResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /ahp/sensitivity/{collection_id} [get]
+func (h *handler) CalculateSensitivity(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.SensitivityRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.CalculateSensitivityByCollectionID(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// CalculateRankReversal
+// @Summary Check Rank Reversal by Collection ID
+// @Description Remove every alternative in turn and report whether the remaining alternatives keep their order, nothing is stored
+// @Tags AHP
+// @Accept json
+// @Produce json
+// @Param collection_id path string true "collection_id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param synthesis query string false "synthesis mode, defaults to the one of the collection" Enums(distributive, ideal, topsis)
+// @Success 200 {object} dto.RankReversalResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /ahp/rank_reversal/{collection_id} [get]
+func (h *handler) CalculateRankReversal(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.AHPScoreCalculateRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.CalculateRankReversalByCollectionID(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// CalculateComparison
+// @Summary Compare AHP and PROMETHEE II by Collection ID
+// @Description Rank the alternatives by the AHP score and by the PROMETHEE II net flow with the AHP criteria weights, preference functions (usual, u_shape, v_shape, linear, gaussian) are set per leaf criteria and default to usual, thresholds are in the unit of the alternative points, nothing is stored
+// @Tags AHP
+// @Accept json
+// @Produce json
+// @Param collection_id path string true "collection_id path"
+// @Param request body dto.ComparisonRequest true "request body"
+// @Success 200 {object} dto.ComparisonResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /ahp/comparison/{collection_id} [post]
+func (h *handler) CalculateComparison(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.ComparisonRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.CalculateComparisonByCollectionID(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// CalculateSMAA
+// @Summary Calculate SMAA by Collection ID
+// @Description Sample the criteria weights around the AHP weights, from a dirichlet distribution or from perturbed pairwise judgments, and report the rank acceptability indices and central weights of every alternative, the seed is returned so a run can be reproduced, nothing is stored
+// @Tags AHP
+// @Accept json
+// @Produce json
+// @Param collection_id path string true "collection_id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param synthesis query string false "synthesis mode, defaults to the one of the collection" Enums(distributive, ideal, topsis)
+// @Param samples query int false "number of samples, default 2000"
+// @Param sampling query string false "weight sampling, default dirichlet" Enums(dirichlet, perturbation)
+// @Param concentration query number false "dirichlet concentration, default 100"
+// @Param spread query number false "perturbation of the judgments in log scale, default 0.25"
+// @Param workers query int false "number of workers, at most 8"
+// @Param seed query int false "seed, 0 included, random only when omitted"
+// @Success 200 {object} dto.SMAAResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /ahp/smaa/{collection_id} [get]
+func (h *handler) CalculateSMAA(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.SMAARequest)
 	if err = c.Bind(payload); err != nil {
 		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
 	}
@@ -217,7 +462,7 @@ func (h *handler) CalculateFinalScores(c echo.Context) error {
 		return response.Send(c)
 	}
 
-	result, err := h.service.CalculateFinalScoreByCollectionID(ctx, &payload.CollectionID)
+	result, err := h.service.CalculateSMAAByCollectionID(ctx, payload)
 	if err != nil {
 		return response.ErrorResponse(err).Send(c)
 	}
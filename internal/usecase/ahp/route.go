@@ -5,9 +5,16 @@ import "github.com/labstack/echo/v4"
 func (h *handler) Route(g *echo.Group) {
 	g.GET("/criteria", h.GetCriteria)
 	g.PATCH("/criteria", h.UpdateCriteriaAlternative)
+	g.GET("/criteria/diagnosis", h.GetCriteriaDiagnosis)
+	g.GET("/subcriteria", h.GetSubCriteriaCatalog)
+	g.GET("/hierarchy/:collection_id", h.GetHierarchy)
 	g.GET("/scores/:collection_id", h.GetScores)
 	g.GET("/final_scores/:collection_id", h.GetFinalScores)
 	g.GET("/point/calculate/:collection_id", h.CalculateAlternativeToPoint)
 	g.GET("/scores/calculate/:collection_id", h.CalculateScores)
 	g.GET("/final_scores/calculate/:collection_id", h.CalculateFinalScores)
+	g.GET("/sensitivity/:collection_id", h.CalculateSensitivity)
+	g.GET("/rank_reversal/:collection_id", h.CalculateRankReversal)
+	g.POST("/comparison/:collection_id", h.CalculateComparison)
+	g.GET("/smaa/:collection_id", h.CalculateSMAA)
 }
@@ -2,12 +2,14 @@ package ahp
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"os"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
 	"ta13-svc/internal/abstraction"
 	dto "ta13-svc/internal/dto/ahp"
 	"ta13-svc/internal/entity"
@@ -16,29 +18,52 @@ import (
 	"ta13-svc/pkg/response"
 	"ta13-svc/pkg/utils/ahp"
 	"ta13-svc/pkg/utils/constant"
+	"time"
 )
 
 type Service interface {
-	FindCriteriaAlternative(ctx context.Context) (*entity.CriteriaData, error)
+	FindCriteriaAlternative(ctx context.Context, method string, scale string) (*entity.CriteriaData, error)
+	FindCriteriaDiagnosis(ctx context.Context, payload *dto.CriteriaDiagnosisRequest) (*dto.CriteriaDiagnosisResponse, error)
+	FindCriteriaWeightsByCollectionID(ctx context.Context, collectionID *string, method string) (*entity.CriteriaWeights, error)
+	FindHierarchyByCollectionID(ctx context.Context, collectionID *string, method string) (*entity.CriteriaWeights, error)
 	FindScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error)
 	FindFinalScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error)
+	FindSubCriteriaCatalog(ctx context.Context) (*dto.SubCriteriaCatalogResponse, error)
 
-	UpdateCriteriaAlternative(ctx context.Context, c *entity.CriteriaData) (*entity.CriteriaData, error)
+	UpdateCriteriaAlternative(ctx context.Context, c *dto.CriteriaAlternativeUpdateRequest) (*entity.CriteriaData, error)
 
-	CalculateAlternativeToPoint(ctx context.Context, collectionID *string) (entity.Matrix, error)
-	CalculateScoreAlternativeByCollectionID(ctx context.Context, collectionID *string) ([]entity.ScoreEntityModel, error)
-	CalculateFinalScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.FinalScoreEntityModel, error)
+	CalculateAlternativeToPoint(ctx context.Context, collectionID *string, synthesis string) (entity.Matrix, error)
+	CalculateScoreAlternativeByCollectionID(ctx context.Context, collectionID *string, method string, synthesis string) ([]entity.ScoreEntityModel, error)
+	CalculateFinalScoreByCollectionID(ctx context.Context, collectionID *string, method string, synthesis string, tiePolicy string) ([]entity.FinalScoreEntityModel, error)
+	CalculateSensitivityByCollectionID(ctx context.Context, payload *dto.SensitivityRequest) (*dto.SensitivityResponse, error)
+	CalculateRankReversalByCollectionID(ctx context.Context, payload *dto.AHPScoreCalculateRequest) (*dto.RankReversalResponse, error)
+	CalculateComparisonByCollectionID(ctx context.Context, payload *dto.ComparisonRequest) (*dto.ComparisonResponse, error)
+	CalculateSMAAByCollectionID(ctx context.Context, payload *dto.SMAARequest) (*dto.SMAAResponse, error)
 }
 
 type service struct {
-	Repository repository.AhpRepository
-	Db         *gorm.DB
+	Repository                   repository.AhpRepository
+	CriteriaMatrixRepository     repository.CriteriaMatrixRepository
+	CriteriaRepository           repository.CriteriaRepository
+	SubCriteriaRepository        repository.SubCriteriaRepository
+	CollectionRepository         repository.CollectionRepository
+	AlternativeMatrixRepository  repository.AlternativeMatrixRepository
+	CriteriaDependencyRepository repository.CriteriaDependencyRepository
+	ValueFunctionRepository      repository.ValueFunctionRepository
+	Db                           *gorm.DB
 }
 
 func NewService(f *factory.Factory) *service {
 	repository := f.AHPRepository
+	criteriaMatrixRepository := f.CriteriaMatrixRepository
+	criteriaRepository := f.CriteriaRepository
+	subCriteriaRepository := f.SubCriteriaRepository
+	collectionRepository := f.CollectionRepository
+	alternativeMatrixRepository := f.AlternativeMatrixRepository
+	criteriaDependencyRepository := f.CriteriaDependencyRepository
+	valueFunctionRepository := f.ValueFunctionRepository
 	db := f.Db
-	return &service{repository, db}
+	return &service{repository, criteriaMatrixRepository, criteriaRepository, subCriteriaRepository, collectionRepository, alternativeMatrixRepository, criteriaDependencyRepository, valueFunctionRepository, db}
 }
 
 func (s *service) FindScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.AlternativeEntityModel, error) {
@@ -71,133 +96,535 @@ func (s *service) FindFinalScoreByCollectionID(ctx context.Context, collectionID
 	return datas, nil
 }
 
-func (s *service) FindCriteriaAlternative(ctx context.Context) (*entity.CriteriaData, error) {
-	var result *entity.CriteriaData
+// FindSubCriteriaCatalog lists the sub criteria options of every leaf criteria in their sort order, the code of
+// an option is the value stored for an alternative.
+func (s *service) FindSubCriteriaCatalog(ctx context.Context) (*dto.SubCriteriaCatalogResponse, error) {
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
 
-	jsonFile, err := os.ReadFile("asset/pairwise.json")
+	subCriterias, err := s.SubCriteriaRepository.FindAll(ctx)
 	if err != nil {
-		fmt.Println(err)
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	options := make(map[string][]dto.SubCriteriaOption)
+	for _, sub := range subCriterias {
+		//LABEL INGGRIS KOSONG MENGGUNAKAN LABEL INDONESIA
+		labelEN := sub.LabelEN
+		if labelEN == "" {
+			labelEN = sub.Label
+		}
+
+		options[sub.CriteriaID] = append(options[sub.CriteriaID], dto.SubCriteriaOption{
+			Code:   sub.Code,
+			Label:  dto.SubCriteriaLabel{ID: sub.Label, EN: labelEN},
+			Weight: sub.Weight,
+			Sort:   sub.Sort,
+			Min:    sub.Min,
+			Max:    sub.Max,
+		})
+	}
+
+	result := &dto.SubCriteriaCatalogResponse{Criterias: make([]dto.SubCriteriaCatalogCriteria, 0)}
+	for _, criteria := range entity.LeafCriterias(criterias) {
+		catalog := dto.SubCriteriaCatalogCriteria{
+			CriteriaID: criteria.ID,
+			Code:       criteria.Code,
+			Name:       criteria.Name,
+			Sort:       criteria.Sort,
+			Options:    options[criteria.ID],
+		}
+		if catalog.Options == nil {
+			catalog.Options = make([]dto.SubCriteriaOption, 0)
+		}
+		result.Criterias = append(result.Criterias, catalog)
 	}
 
-	var criteriaData entity.CriteriaData
-	err = json.Unmarshal(jsonFile, &criteriaData)
+	return result, nil
+}
+
+func (s *service) FindCriteriaAlternative(ctx context.Context, method string, scale string) (*entity.CriteriaData, error) {
+	template, err := s.CriteriaMatrixRepository.FindDefault(ctx)
 	if err != nil {
-		fmt.Println(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
 	}
 
-	//TODO:Masih kurang efektif
-	var criteriaDataUnedited entity.CriteriaData
-	err = json.Unmarshal(jsonFile, &criteriaDataUnedited)
+	criteriaData, err := s.newCriteriaData(ctx, template.CriteriaMatrixEntity, method)
 	if err != nil {
-		fmt.Println(err)
+		return nil, err
 	}
 
-	//MENCARI SUM DARI MASING MASING COL
-	rowsPC := len(criteriaData.PairwiseFromJson)
-	colsPC := len(criteriaData.PairwiseFromJson[0])
-	colSum := make([]float64, len(criteriaData.PairwiseFromJson))
+	//TANPA PARAMETER, SKALA YANG DIPAKAI SAAT PENILAIAN
+	if scale == "" {
+		scale = template.Scale
+	}
+	if err = criteriaData.SetLinguistic(scale); err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.BadRequest, err)
+	}
 
-	for i := 0; i < rowsPC; i++ {
-		for j := 0; j < colsPC; j++ {
-			colSum[i] += criteriaData.PairwiseFromJson[j][i]
-		}
+	return criteriaData, nil
+}
+
+func (s *service) FindCriteriaDiagnosis(ctx context.Context, payload *dto.CriteriaDiagnosisRequest) (*dto.CriteriaDiagnosisResponse, error) {
+	criteriaData, err := s.FindCriteriaAlternative(ctx, payload.Method, "")
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions, err := ahp.Diagnose(criteriaData.PairwiseFromJson, criteriaData.Method)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	result := &dto.CriteriaDiagnosisResponse{
+		CriteriaData: *criteriaData,
+		Suggestions:  judgmentDiagnosis(suggestions, criteriaData.CriteriaCodes),
+	}
+
+	if !payload.AutoRepair {
+		return result, nil
+	}
+
+	//PERBAIKAN OTOMATIS SAMPAI CR <= 0.1, TIDAK DISIMPAN
+	repaired, applied, consistent, err := ahp.Repair(criteriaData.PairwiseFromJson, criteriaData.Method)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	repairedData, err := s.newCriteriaData(ctx, entity.CriteriaMatrixEntity{Pairwise: repaired}, criteriaData.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Repair = &dto.CriteriaRepair{
+		Applied:    judgmentDiagnosis(applied, criteriaData.CriteriaCodes),
+		Repaired:   *repairedData,
+		Consistent: consistent,
+	}
+	if !consistent {
+		result.Repair.Message = fmt.Sprintf("Repair stopped after %d revisions with CR %.3f > %.1f, revise the remaining judgments manually",
+			len(applied), repairedData.ConsistencyRatio, ahp.ConsistencyThreshold)
+	}
+
+	return result, nil
+}
+
+func judgmentDiagnosis(suggestions []ahp.JudgmentSuggestion, codes []string) []dto.JudgmentDiagnosis {
+	datas := make([]dto.JudgmentDiagnosis, 0)
+	for _, suggestion := range suggestions {
+		datas = append(datas, dto.JudgmentDiagnosis{
+			JudgmentSuggestion: suggestion,
+			RowCode:            codes[suggestion.Row],
+			ColCode:            codes[suggestion.Col],
+		})
+	}
+	return datas
+}
+
+// FindCriteriaWeightsByCollectionID returns the global weights of the leaf criteria, in the anp decision model
+// they are the limit priorities of the criteria network.
+func (s *service) FindCriteriaWeightsByCollectionID(ctx context.Context, collectionID *string, method string) (*entity.CriteriaWeights, error) {
+	criterias, matrices, template, err := s.findCriteriaMatrices(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := s.findCriteriaDependencies(ctx, collectionID)
+	if err != nil {
+		return nil, err
 	}
 
-	//NORMALISASI MATRIKS PAIRWISE
-	for i := 0; i < rowsPC; i++ {
-		for j := 0; j < colsPC; j++ {
-			criteriaData.PairwiseFromJson[i][j] /= colSum[j]
+	criteriaWeights, err := networkWeights(criterias, matrices, template, dependencies, method)
+	if err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	return criteriaWeights, nil
+}
+
+// findCriteriaDependencies loads the criteria dependencies of a collection in the anp decision model, nil otherwise.
+func (s *service) findCriteriaDependencies(ctx context.Context, collectionID *string) ([]entity.CriteriaDependencyEntityModel, error) {
+	collection, err := s.CollectionRepository.FindByID(ctx, collectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
 		}
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if collection.DecisionModel != entity.DecisionModelANP {
+		return nil, nil
+	}
+
+	dependencies, err := s.CriteriaDependencyRepository.FindByCollectionID(ctx, collectionID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if dependencies == nil {
+		dependencies = make([]entity.CriteriaDependencyEntityModel, 0)
+	}
+
+	return dependencies, nil
+}
+
+// networkWeights computes the hierarchy weights, a non nil dependencies replaces them by the limit priorities.
+func networkWeights(criterias []entity.CriteriaEntityModel, matrices map[string]entity.CriteriaMatrixEntity, template entity.Matrix, dependencies []entity.CriteriaDependencyEntityModel, method string) (*entity.CriteriaWeights, error) {
+	criteriaWeights, err := entity.NewCriteriaWeights(criterias, matrices, template, method)
+	if err != nil {
+		return nil, err
+	}
+	if dependencies == nil {
+		return criteriaWeights, nil
+	}
+
+	if err = criteriaWeights.ApplyNetwork(criterias, dependencies, method); err != nil {
+		return nil, err
+	}
+	return criteriaWeights, nil
+}
+
+// findCriteriaMatrices loads the criteria, the pairwise matrices of the collection keyed by node id and the template.
+func (s *service) findCriteriaMatrices(ctx context.Context, collectionID *string) ([]entity.CriteriaEntityModel, map[string]entity.CriteriaMatrixEntity, entity.Matrix, error) {
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criteriaMatrices, err := s.CriteriaMatrixRepository.FindAllByCollectionID(ctx, collectionID)
+	if err != nil {
+		return nil, nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
 	}
 
-	//MENCARI JUMLAH NILAI BARIS DAN KOLOM & MENCARI RATA RATA (BOBOT KRITERIA)
-	normalColSum := make([]float64, len(criteriaData.PairwiseFromJson))
-	normalRowSum := make([]float64, len(criteriaData.PairwiseFromJson))
-	criteriaWeights := make([]float64, len(criteriaData.PairwiseFromJson))
+	matrices := make(map[string]entity.CriteriaMatrixEntity)
+	for _, criteriaMatrix := range criteriaMatrices {
+		matrices[criteriaMatrix.NodeID] = criteriaMatrix.CriteriaMatrixEntity
+	}
 
-	for i := 0; i < rowsPC; i++ {
-		sum := 0.0
-		for j := 0; j < colsPC; j++ {
-			sum += criteriaData.PairwiseFromJson[i][j]
-			normalColSum[i] += criteriaData.PairwiseFromJson[j][i]
-			normalRowSum[i] += criteriaData.PairwiseFromJson[i][j]
-			criteriaWeights[i] = sum / float64(len(criteriaWeights))
+	//TANPA TEMPLATE, GOAL DIBOBOT RATA
+	template, err := s.CriteriaMatrixRepository.FindDefault(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return criterias, matrices, nil, nil
 		}
+		return nil, nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
 	}
 
-	result = &entity.CriteriaData{
-		PairwiseFromJson:        criteriaDataUnedited.PairwiseFromJson,
-		PairwiseAfterCalculated: criteriaData.PairwiseFromJson,
-		Criteria:                criteriaWeights}
+	return criterias, matrices, template.Pairwise, nil
+}
 
-	return result, nil
+func (s *service) FindHierarchyByCollectionID(ctx context.Context, collectionID *string, method string) (*entity.CriteriaWeights, error) {
+	_, err := s.CollectionRepository.FindByID(ctx, collectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	return s.FindCriteriaWeightsByCollectionID(ctx, collectionID, method)
 }
 
 func (s *service) UpdateCriteriaAlternative(ctx context.Context, c *dto.CriteriaAlternativeUpdateRequest) (*entity.CriteriaData, error) {
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
 
-	jsonFile, err := os.ReadFile("asset/pairwise.json")
-	var criteriaData entity.CriteriaData
+	children := entity.ChildCriterias(criterias, entity.GoalNodeID)
+	if c.BestWorst != nil {
+		return s.updateCriteriaAlternativeBestWorst(ctx, c, children)
+	}
+
+	//MEMBANGUN MATRIKS RESIPROKAL DARI INPUT
+	incomplete, errs := c.PairwiseInput.Build(len(children))
+	if len(errs) > 0 {
+		return nil, response.InputErrorBuilder(errs)
+	}
 
-	err = json.Unmarshal(jsonFile, &criteriaData)
+	//MELENGKAPI PERBANDINGAN YANG KOSONG (NULL)
+	pairwise, estimated, err := ahp.Complete(incomplete, c.Completion)
+	if err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
 
+	calculated, err := s.newCriteriaData(ctx, entity.CriteriaMatrixEntity{Pairwise: pairwise}, c.Method)
 	if err != nil {
 		return nil, err
 	}
 
-	criteriaData.PairwiseFromJson = c.Pairwise
+	if len(estimated) > 0 {
+		calculated.Completion = c.Completion
+		if calculated.Completion == "" {
+			calculated.Completion = ahp.CompletionHarker
+		}
+		calculated.EstimatedCells = estimated
+	}
 
-	b, err := json.Marshal(criteriaData)
+	if err = calculated.SetLinguistic(c.Scale); err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.BadRequest, err)
+	}
+
+	if !calculated.IsConsistent && !c.Force {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Pairwise matrix is", calculated.ConsistencyRatio))
+	}
+
+	_, err = s.CriteriaMatrixRepository.UpdateDefault(ctx, &entity.CriteriaMatrixEntityModel{
+		Entity:               abstraction.Entity{ID: uuid.NewString()},
+		CriteriaMatrixEntity: entity.CriteriaMatrixEntity{Pairwise: pairwise, Scale: c.Scale},
+	})
 	if err != nil {
-		return nil, err
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	return calculated, nil
+}
+
+// updateCriteriaAlternativeBestWorst stores the BWM judgments with the consistent matrix of their weights as the
+// template, the weights, ξ and CR are read back from the judgments whatever the method.
+func (s *service) updateCriteriaAlternativeBestWorst(ctx context.Context, c *dto.CriteriaAlternativeUpdateRequest, children []entity.CriteriaEntityModel) (*entity.CriteriaData, error) {
+	if errs := c.BestWorst.Validate("best_worst", len(children)); len(errs) > 0 {
+		return nil, response.InputErrorBuilder(errs)
 	}
 
-	err = os.WriteFile("asset/pairwise.json", b, 0644)
+	calculated, err := entity.NewBestWorstCriteriaData(*c.BestWorst)
 	if err != nil {
-		return nil, err
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+	if err = calculated.SetCriteria(children); err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
 	}
 
-	fmt.Println(string(b))
-	result := &entity.CriteriaData{
-		PairwiseFromJson: criteriaData.PairwiseFromJson,
+	if !calculated.IsConsistent && !c.Force {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Best worst judgments are", calculated.ConsistencyRatio))
 	}
 
-	return result, nil
+	_, err = s.CriteriaMatrixRepository.UpdateDefault(ctx, &entity.CriteriaMatrixEntityModel{
+		Entity:               abstraction.Entity{ID: uuid.NewString()},
+		CriteriaMatrixEntity: entity.CriteriaMatrixEntity{Pairwise: calculated.PairwiseFromJson, BestWorst: c.BestWorst},
+	})
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	return calculated, nil
 }
 
-func (s *service) CalculateAlternativeToPoint(ctx context.Context, collectionID *string) (entity.Matrix, error) {
-	alternatives := make([]entity.AlternativeEntityModel, 0)
-	alternatives, err = s.Repository.FindAlternativesByCollectionID(ctx, collectionID)
+func (s *service) newCriteriaData(ctx context.Context, criteriaMatrix entity.CriteriaMatrixEntity, method string) (*entity.CriteriaData, error) {
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
 
+	criteriaData, err := criteriaMatrix.CriteriaData(method)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	if err = criteriaData.SetCriteria(entity.ChildCriterias(criterias, entity.GoalNodeID)); err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	return criteriaData, nil
+}
+
+func (s *service) CalculateAlternativeToPoint(ctx context.Context, collectionID *string, synthesis string) (entity.Matrix, error) {
+	_, matrix, err := s.alternativePoints(ctx, collectionID, synthesis)
+	return matrix, err
+}
+
+// alternativePoints returns the alternatives with the synthesized matrix whose rows follow them, callers pair
+// rows with these alternatives and never with another query.
+func (s *service) alternativePoints(ctx context.Context, collectionID *string, synthesis string) ([]entity.AlternativeEntityModel, entity.Matrix, error) {
+	alternatives, err := s.Repository.FindAlternativesByCollectionID(ctx, collectionID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+			return nil, nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
 		}
-		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		return nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
 	}
 
 	if len(alternatives) == 0 {
-		return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		return nil, nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+	}
+
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	criterias = entity.LeafCriterias(criterias)
+
+	collection, err := s.CollectionRepository.FindByID(ctx, collectionID)
+	if err != nil {
+		return nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if synthesis == "" {
+		synthesis = collection.Synthesis
+	}
+
+	matrix, ideals, err := s.alternativeMatrix(ctx, collection, alternatives, criterias)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	synthesized, err := ahp.Synthesize(matrix, synthesis, ideals)
+	if err != nil {
+		return nil, nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	return alternatives, synthesized, nil
+}
+
+// alternativeMatrix returns the local priority of every alternative on every leaf criteria, from the
+// sub-criteria ratings or from the pairwise comparisons depending on the scoring mode. In the ratings mode
+// ideals holds the best sub criteria intensity of every criteria, a value function score is multiplied by it so
+// both kinds of column share the rating scale. The pairwise mode measures relatively and has no ideals.
+func (s *service) alternativeMatrix(ctx context.Context, collection *entity.CollectionEntityModel, alternatives []entity.AlternativeEntityModel, criterias []entity.CriteriaEntityModel) (entity.Matrix, []float64, error) {
+	if collection.ScoringMode == entity.ScoringModePairwise {
+		matrix, err := s.alternativePriorities(ctx, collection, alternatives, criterias)
+		return matrix, nil, err
+	}
+
+	subCriterias, err := s.SubCriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	subCriteria := make(map[string]map[string]float64)
+	bestIntensity := make(map[string]float64)
+	for _, sub := range subCriterias {
+		if subCriteria[sub.CriteriaID] == nil {
+			subCriteria[sub.CriteriaID] = make(map[string]float64)
+		}
+		subCriteria[sub.CriteriaID][sub.Code] = sub.Weight
+		if sub.Weight > bestIntensity[sub.CriteriaID] {
+			bestIntensity[sub.CriteriaID] = sub.Weight
+		}
+	}
+
+	valueFunctions, err := s.ValueFunctionRepository.FindByCollectionID(ctx, &collection.ID)
+	if err != nil {
+		return nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	functions := make(map[string]ahp.ValueFunction)
+	for _, valueFunction := range valueFunctions {
+		functions[valueFunction.CriteriaID] = valueFunction.Function()
+	}
+
+	//FUNGSI NILAI DISKALAKAN KE INTENSITAS TERBAIK, TANPA SUB KRITERIA TETAP 0..1
+	ideals := make([]float64, len(criterias))
+	for j, criteria := range criterias {
+		ideals[j] = bestIntensity[criteria.ID]
+		if _, ok := functions[criteria.ID]; ok && ideals[j] <= 0 {
+			ideals[j] = 1
+		}
 	}
 
 	matrix := make(entity.Matrix, 0)
+	missing := make([]string, 0)
+	invalid := make([]string, 0)
 
 	for i := 0; i < len(alternatives); i++ {
-		row := entity.Matrix{
-			{ahp.TimbulanSampahSubCriteria()[alternatives[i].TimbulanSampah],
-				ahp.JarakTPASubCriteria()[alternatives[i].JarakTpa],
-				ahp.JarakPemukimanSubCriteria()[alternatives[i].JarakPemukiman],
-				ahp.JarakSungaiSubCriteria()[alternatives[i].JarakSungai],
-				ahp.PartisipasiMasyarakatSubCriteria()[alternatives[i].PartisipasiMasyarakat],
-				ahp.CakupanRumahSubCriteria()[alternatives[i].CakupanRumah],
-				ahp.AksesibilitasSubCriteria()[alternatives[i].Aksesibilitas]}}
+		values := make(map[string]entity.AlternativeValueEntity)
+		for _, value := range alternatives[i].Values {
+			values[value.CriteriaID] = value.AlternativeValueEntity
+		}
+
+		row := make([]float64, len(criterias))
+		for j, criteria := range criterias {
+			value := values[criteria.ID]
+			function, ok := functions[criteria.ID]
+			if !ok {
+				//NILAI DI LUAR KATALOG SUB KRITERIA TIDAK BOLEH DIANGGAP 0
+				weight, ok := subCriteria[criteria.ID][value.Value]
+				if !ok {
+					invalid = append(invalid, fmt.Sprintf("%s (%s: %q)", alternatives[i].Nama, criteria.Code, value.Value))
+					continue
+				}
+				row[j] = weight
+				continue
+			}
+
+			//FUNGSI NILAI MEMBUTUHKAN NILAI MENTAH
+			if value.Raw == nil {
+				missing = append(missing, fmt.Sprintf("%s (%s)", alternatives[i].Nama, criteria.Code))
+				continue
+			}
+			row[j] = function.RatingValue(*value.Raw, bestIntensity[criteria.ID])
+		}
+
+		matrix = append(matrix, row)
+	}
+
+	if len(invalid) > 0 {
+		return nil, nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			fmt.Sprintf("Alternatives have values outside the sub criteria catalog: %s", strings.Join(invalid, ", ")))
+	}
+	if len(missing) > 0 {
+		return nil, nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			fmt.Sprintf("Value functions need a raw value, missing for %s", strings.Join(missing, ", ")))
+	}
+
+	return matrix, ideals, nil
+}
+
+// alternativePriorities fills every leaf criteria column with the local priorities of the alternatives
+// from their pairwise matrix on that criteria.
+func (s *service) alternativePriorities(ctx context.Context, collection *entity.CollectionEntityModel, alternatives []entity.AlternativeEntityModel, criterias []entity.CriteriaEntityModel) (entity.Matrix, error) {
+	alternativeMatrices, err := s.AlternativeMatrixRepository.FindByCollectionID(ctx, &collection.ID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	matrixByCriteria := make(map[string]entity.AlternativeMatrixEntity)
+	for _, m := range alternativeMatrices {
+		matrixByCriteria[m.CriteriaID] = m.AlternativeMatrixEntity
+	}
 
-		matrix = append(matrix, row...)
+	alternativeIDs := make([]string, len(alternatives))
+	for i, alternative := range alternatives {
+		alternativeIDs[i] = alternative.ID
+	}
+
+	matrix := make(entity.Matrix, len(alternatives))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(criterias))
+	}
+
+	missing := make([]string, 0)
+	for j, criteria := range criterias {
+		alternativeMatrix, ok := matrixByCriteria[criteria.ID]
+		if !ok {
+			missing = append(missing, criteria.Code)
+			continue
+		}
+
+		criteriaData, err := alternativeMatrix.CriteriaData(alternativeIDs, collection.WeightMethod)
+		if err != nil {
+			return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+				fmt.Sprintf("Criteria %s: %s, resubmit the alternative comparisons", criteria.Code, err.Error()))
+		}
+
+		for i := range matrix {
+			matrix[i][j] = criteriaData.Criteria[i]
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			fmt.Sprintf("Alternatives have not been compared on criteria %s", strings.Join(missing, ", ")))
 	}
 
 	return matrix, nil
 }
 
-func (s *service) CalculateScoreAlternativeByCollectionID(ctx context.Context, collectionID *string) ([]entity.ScoreEntityModel, error) {
+func (s *service) CalculateScoreAlternativeByCollectionID(ctx context.Context, collectionID *string, method string, synthesis string) ([]entity.ScoreEntityModel, error) {
 	alternatives := make([]entity.AlternativeEntityModel, 0)
 	alternatives, err = s.Repository.FindAlternativesByCollectionID(ctx, collectionID)
 	var collection *entity.CollectionEntityModel
@@ -231,21 +658,35 @@ func (s *service) CalculateScoreAlternativeByCollectionID(ctx context.Context, c
 		}
 	}
 
-	matrix := make(entity.Matrix, 0)
-	matrix, err = s.CalculateAlternativeToPoint(ctx, collectionID)
+	//TANPA METHOD ATAU SINTESIS, GUNAKAN YANG DIPILIH PADA KOLEKSI
+	if method == "" || synthesis == "" {
+		collection, err = s.CollectionRepository.FindByID(ctx, collectionID)
+		if err != nil {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+		if method == "" {
+			method = collection.WeightMethod
+		}
+		if synthesis == "" {
+			synthesis = collection.Synthesis
+		}
+	}
 
+	//BARIS MATRIKS MENGIKUTI ALTERNATIF DARI QUERY YANG SAMA
+	alternatives, matrix, err := s.alternativePoints(ctx, collectionID, synthesis)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
-		}
-		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		return nil, err
 	}
 
-	criteriaData, _ := s.FindCriteriaAlternative(ctx)
-	criteriaWeights := criteriaData.Criteria
+	criteriaData, err := s.FindCriteriaWeightsByCollectionID(ctx, collectionID, method)
+	if err != nil {
+		return nil, err
+	}
+	criteriaWeights := criteriaData.Weights
+	criterias := criteriaData.Leaves
 
 	rowsACS := len(matrix)
-	colsACS := len(matrix[0])
+	colsACS := len(criterias)
 
 	//PERKALIAN MATRIKS ALTERNATIF DENGAN MATRIKS BOBOT
 	for i := 0; i < rowsACS; i++ {
@@ -256,21 +697,18 @@ func (s *service) CalculateScoreAlternativeByCollectionID(ctx context.Context, c
 
 	scores := make([]entity.ScoreEntityModel, 0)
 
-	for i := 0; i < len(matrix); i++ {
-		scores = append(scores, entity.ScoreEntityModel{
-			ScoreEntity: entity.ScoreEntity{
-				TimbulanSampah:        constant.RoundFloat(matrix[i][0], 3),
-				JarakTpa:              constant.RoundFloat(matrix[i][1], 3),
-				JarakPemukiman:        constant.RoundFloat(matrix[i][2], 3),
-				JarakSungai:           constant.RoundFloat(matrix[i][3], 3),
-				PartisipasiMasyarakat: constant.RoundFloat(matrix[i][4], 3),
-				CakupanRumah:          constant.RoundFloat(matrix[i][5], 3),
-				Aksesibilitas:         constant.RoundFloat(matrix[i][6], 3),
-			},
-			Entity:        abstraction.Entity{ID: uuid.NewString()},
-			CollectionID:  alternatives[i].CollectionID,
-			AlternativeID: alternatives[i].ID,
-		})
+	for i := 0; i < rowsACS; i++ {
+		for j := 0; j < colsACS; j++ {
+			scores = append(scores, entity.ScoreEntityModel{
+				ScoreEntity: entity.ScoreEntity{
+					Score: constant.RoundFloat(matrix[i][j], 3),
+				},
+				Entity:        abstraction.Entity{ID: uuid.NewString()},
+				CollectionID:  alternatives[i].CollectionID,
+				AlternativeID: alternatives[i].ID,
+				CriteriaID:    criterias[j].ID,
+			})
+		}
 	}
 
 	_, err = s.Repository.CreateScore(ctx, scores)
@@ -278,6 +716,9 @@ func (s *service) CalculateScoreAlternativeByCollectionID(ctx context.Context, c
 	collection = &entity.CollectionEntityModel{
 		CollectionEntity: entity.CollectionEntity{
 			ScoreIsCalculated: true,
+			WeightMethod:      criteriaData.Method,
+			WeightIterations:  criteriaData.Iterations,
+			Synthesis:         synthesis,
 		},
 	}
 
@@ -291,8 +732,8 @@ func (s *service) CalculateScoreAlternativeByCollectionID(ctx context.Context, c
 
 }
 
-func (s *service) CalculateFinalScoreByCollectionID(ctx context.Context, collectionID *string) ([]entity.FinalScoreEntityModel, error) {
-	alternativeScores, err := s.CalculateScoreAlternativeByCollectionID(ctx, collectionID)
+func (s *service) CalculateFinalScoreByCollectionID(ctx context.Context, collectionID *string, method string, synthesis string, tiePolicy string) ([]entity.FinalScoreEntityModel, error) {
+	alternativeScores, err := s.CalculateScoreAlternativeByCollectionID(ctx, collectionID, method, synthesis)
 	var collection *entity.CollectionEntityModel
 
 	if err != nil {
@@ -319,18 +760,53 @@ func (s *service) CalculateFinalScoreByCollectionID(ctx context.Context, collect
 		}
 	}
 
+	//MENJUMLAHKAN SKOR SETIAP KRITERIA PER ALTERNATIF
 	finalScores := make([]entity.FinalScoreEntityModel, 0)
+	finalScoreIndex := make(map[string]int)
 
 	for i := 0; i < len(alternativeScores); i++ {
-		finalScores = append(finalScores, entity.FinalScoreEntityModel{
-			Entity: abstraction.Entity{ID: uuid.NewString()},
-			FinalScoreEntity: entity.FinalScoreEntity{
-				FinalScore: (alternativeScores[i].TimbulanSampah + alternativeScores[i].JarakTpa + alternativeScores[i].JarakPemukiman + alternativeScores[i].JarakSungai + alternativeScores[i].PartisipasiMasyarakat + alternativeScores[i].CakupanRumah + alternativeScores[i].Aksesibilitas) * 100,
-				Rank:       0,
-			},
-			AlternativeID: alternativeScores[i].AlternativeID,
-			CollectionID:  alternativeScores[i].CollectionID,
-		})
+		index, ok := finalScoreIndex[alternativeScores[i].AlternativeID]
+		if !ok {
+			index = len(finalScores)
+			finalScoreIndex[alternativeScores[i].AlternativeID] = index
+			finalScores = append(finalScores, entity.FinalScoreEntityModel{
+				Entity:        abstraction.Entity{ID: uuid.NewString()},
+				AlternativeID: alternativeScores[i].AlternativeID,
+				CollectionID:  alternativeScores[i].CollectionID,
+			})
+		}
+		finalScores[index].FinalScore += alternativeScores[i].Score * 100
+	}
+
+	//METHOD DAN SINTESIS SUDAH DISIMPAN SAAT MENGHITUNG SKOR
+	collection, err = s.CollectionRepository.FindByID(ctx, collectionID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	if collection.Synthesis == ahp.SynthesisTopsis {
+		if err = s.topsisFinalScores(ctx, collection, finalScores); err != nil {
+			return nil, err
+		}
+	}
+
+	//MENENTUKAN PERINGKAT SESUAI KEBIJAKAN NILAI SAMA
+	if tiePolicy == "" {
+		tiePolicy = collection.TiePolicy
+	}
+
+	scores := make([]float64, len(finalScores))
+	for i := range finalScores {
+		finalScores[i].FinalScore = constant.RoundFloat(finalScores[i].FinalScore, 3)
+		scores[i] = finalScores[i].FinalScore
+	}
+
+	ranks, err := ahp.Ranks(scores, tiePolicy)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+	for i := range finalScores {
+		finalScores[i].Rank = ranks[i]
 	}
 
 	_, err = s.Repository.CreateFinalScore(ctx, finalScores)
@@ -338,6 +814,7 @@ func (s *service) CalculateFinalScoreByCollectionID(ctx context.Context, collect
 	collection = &entity.CollectionEntityModel{
 		CollectionEntity: entity.CollectionEntity{
 			FinalScoreIsCalculated: true,
+			TiePolicy:              tiePolicy,
 		},
 	}
 
@@ -347,5 +824,553 @@ func (s *service) CalculateFinalScoreByCollectionID(ctx context.Context, collect
 		return nil, err
 	}
 
+	//PEMENANG DAN SELISIH SKOR PERINGKAT 1 DAN 2
+	order := ahp.Ranking(scores)
+	gap := 0.0
+	if len(order) > 1 {
+		gap = constant.RoundFloat(scores[order[0]]-scores[order[1]], 3)
+	}
+
+	err = s.Repository.UpdateCollectionWinner(ctx, collectionID, &finalScores[order[0]].AlternativeID, gap)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
 	return finalScores, nil
 }
+
+// topsisFinalScores replaces the weighted sum of every final score by the TOPSIS closeness coefficient.
+func (s *service) topsisFinalScores(ctx context.Context, collection *entity.CollectionEntityModel, finalScores []entity.FinalScoreEntityModel) error {
+	alternatives, matrix, err := s.alternativePoints(ctx, &collection.ID, ahp.SynthesisTopsis)
+	if err != nil {
+		return err
+	}
+
+	criteriaData, err := s.FindCriteriaWeightsByCollectionID(ctx, &collection.ID, collection.WeightMethod)
+	if err != nil {
+		return err
+	}
+
+	results, err := ahp.Topsis(matrix, criteriaData.Weights, entity.PriorityImpacts(criteriaData.Leaves))
+	if err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+	}
+
+	resultByAlternative := make(map[string]ahp.TopsisResult)
+	for i, alternative := range alternatives {
+		resultByAlternative[alternative.ID] = results[i]
+	}
+
+	for i := range finalScores {
+		result := resultByAlternative[finalScores[i].AlternativeID]
+		distancePositive := constant.RoundFloat(result.DistancePositive, 6)
+		distanceNegative := constant.RoundFloat(result.DistanceNegative, 6)
+		closeness := constant.RoundFloat(result.Closeness, 6)
+
+		finalScores[i].FinalScore = result.Closeness * 100
+		finalScores[i].DistancePositive = &distancePositive
+		finalScores[i].DistanceNegative = &distanceNegative
+		finalScores[i].Closeness = &closeness
+	}
+
+	return nil
+}
+
+func (s *service) CalculateSensitivityByCollectionID(ctx context.Context, payload *dto.SensitivityRequest) (*dto.SensitivityResponse, error) {
+	collection, err := s.CollectionRepository.FindByID(ctx, &payload.CollectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	method := payload.Method
+	if method == "" {
+		method = collection.WeightMethod
+	}
+
+	steps, minWeight, maxWeight := payload.Steps, payload.Min, payload.Max
+	if steps == 0 {
+		steps = 10
+	}
+	if maxWeight == 0 {
+		maxWeight = 1
+	}
+	if minWeight > maxWeight {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, errors.New("min must not be greater than max"))
+	}
+
+	alternatives, matrix, err := s.alternativePoints(ctx, &payload.CollectionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	criteriaData, err := s.FindCriteriaWeightsByCollectionID(ctx, &payload.CollectionID, method)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking := func(scores []float64) []string {
+		ids := make([]string, 0)
+		for _, i := range ahp.Ranking(scores) {
+			ids = append(ids, alternatives[i].ID)
+		}
+		return ids
+	}
+
+	result := &dto.SensitivityResponse{
+		CollectionID: payload.CollectionID,
+		Method:       criteriaData.Method,
+		Alternatives: make([]dto.SensitivityAlternative, 0),
+		Ranking:      ranking(ahp.Scores(matrix, criteriaData.Weights)),
+		Criteria:     make([]dto.CriteriaSensitivity, 0),
+	}
+	for _, alternative := range alternatives {
+		result.Alternatives = append(result.Alternatives, dto.SensitivityAlternative{ID: alternative.ID, Nama: alternative.Nama})
+	}
+
+	//SWEEP BOBOT SETIAP KRITERIA, BOBOT LAIN DISKALAKAN PROPORSIONAL
+	for j, criteria := range criteriaData.Leaves {
+		sensitivity := dto.CriteriaSensitivity{
+			CriteriaID: criteria.ID,
+			Code:       criteria.Code,
+			Weight:     criteriaData.Weights[j],
+			Steps:      make([]dto.SensitivityStep, 0),
+			Thresholds: make([]dto.SensitivityRankReversal, 0),
+		}
+
+		for step := 0; step <= steps; step++ {
+			weight := minWeight + (maxWeight-minWeight)*float64(step)/float64(steps)
+			scores := ahp.Scores(matrix, ahp.RescaleWeights(criteriaData.Weights, j, weight))
+			for i := range scores {
+				scores[i] = constant.RoundFloat(scores[i]*100, 3)
+			}
+
+			sensitivity.Steps = append(sensitivity.Steps, dto.SensitivityStep{
+				Weight:  constant.RoundFloat(weight, 3),
+				Scores:  scores,
+				Ranking: ranking(scores),
+			})
+		}
+
+		for _, threshold := range ahp.RankReversalThresholds(matrix, criteriaData.Weights, j, minWeight, maxWeight) {
+			sensitivity.Thresholds = append(sensitivity.Thresholds, dto.SensitivityRankReversal{
+				Weight: threshold.Weight,
+				From:   alternatives[threshold.From].ID,
+				To:     alternatives[threshold.To].ID,
+				Rank:   threshold.Rank,
+			})
+		}
+
+		result.Criteria = append(result.Criteria, sensitivity)
+	}
+
+	return result, nil
+}
+
+func (s *service) CalculateRankReversalByCollectionID(ctx context.Context, payload *dto.AHPScoreCalculateRequest) (*dto.RankReversalResponse, error) {
+	collection, err := s.CollectionRepository.FindByID(ctx, &payload.CollectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	method, synthesis := payload.Method, payload.Synthesis
+	if method == "" {
+		method = collection.WeightMethod
+	}
+	if synthesis == "" {
+		synthesis = collection.Synthesis
+	}
+
+	alternatives, err := s.Repository.FindAlternativesByCollectionID(ctx, &payload.CollectionID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if len(alternatives) < 2 {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			"Rank reversal check needs at least 2 alternatives")
+	}
+
+	criteriaData, err := s.FindCriteriaWeightsByCollectionID(ctx, &payload.CollectionID, method)
+	if err != nil {
+		return nil, err
+	}
+
+	full, err := s.synthesizedScores(ctx, collection, alternatives, criteriaData, synthesis)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.RankReversalResponse{
+		CollectionID: payload.CollectionID,
+		Method:       criteriaData.Method,
+		Synthesis:    synthesis,
+		Ranking:      alternativeRanking(alternatives, full),
+		Removals:     make([]dto.RankReversalRemoval, 0),
+	}
+
+	//HAPUS SETIAP ALTERNATIF LALU BANDINGKAN URUTAN SISANYA
+	for k, removed := range alternatives {
+		rest := make([]entity.AlternativeEntityModel, 0)
+		rest = append(rest, alternatives[:k]...)
+		rest = append(rest, alternatives[k+1:]...)
+
+		reduced, err := s.synthesizedScores(ctx, collection, rest, criteriaData, synthesis)
+		if err != nil {
+			return nil, err
+		}
+
+		preserved := ahp.OrderPreserved(full, reduced, k)
+		if !preserved {
+			result.RankReversal = true
+		}
+
+		result.Removals = append(result.Removals, dto.RankReversalRemoval{
+			AlternativeID: removed.ID,
+			Nama:          removed.Nama,
+			Ranking:       alternativeRanking(rest, reduced),
+			Preserved:     preserved,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *service) CalculateComparisonByCollectionID(ctx context.Context, payload *dto.ComparisonRequest) (*dto.ComparisonResponse, error) {
+	collection, err := s.CollectionRepository.FindByID(ctx, &payload.CollectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	method, synthesis := payload.Method, payload.Synthesis
+	if method == "" {
+		method = collection.WeightMethod
+	}
+	if synthesis == "" {
+		synthesis = collection.Synthesis
+	}
+
+	alternatives, err := s.Repository.FindAlternativesByCollectionID(ctx, &payload.CollectionID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if len(alternatives) < 2 {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			"Comparison needs at least 2 alternatives")
+	}
+
+	criteriaData, err := s.FindCriteriaWeightsByCollectionID(ctx, &payload.CollectionID, method)
+	if err != nil {
+		return nil, err
+	}
+
+	//FUNGSI PREFERENSI PER KRITERIA DAUN, DEFAULT USUAL
+	leaves := make(map[string]bool)
+	for _, criteria := range criteriaData.Leaves {
+		leaves[criteria.ID] = true
+	}
+	functionByCriteria := make(map[string]ahp.PreferenceFunction)
+	for _, f := range payload.PreferenceFunctions {
+		if !leaves[f.CriteriaID] {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, fmt.Errorf("criteria %s is not a leaf criteria", f.CriteriaID))
+		}
+		functionByCriteria[f.CriteriaID] = f.PreferenceFunction
+	}
+
+	functions := make([]ahp.PreferenceFunction, 0)
+	preferenceFunctions := make([]dto.CriteriaPreferenceFunction, 0)
+	for _, criteria := range criteriaData.Leaves {
+		f := functionByCriteria[criteria.ID]
+		if f.Type == "" {
+			f.Type = ahp.PreferenceUsual
+		}
+		if err = f.Validate(); err != nil {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, fmt.Errorf("criteria %s: %s", criteria.Code, err.Error()))
+		}
+		functions = append(functions, f)
+		preferenceFunctions = append(preferenceFunctions, dto.CriteriaPreferenceFunction{CriteriaID: criteria.ID, PreferenceFunction: f})
+	}
+
+	ahpScores, err := s.synthesizedScores(ctx, collection, alternatives, criteriaData, synthesis)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix, _, err := s.alternativeMatrix(ctx, collection, alternatives, criteriaData.Leaves)
+	if err != nil {
+		return nil, err
+	}
+
+	flows, err := ahp.PrometheeFlows(matrix, criteriaData.Weights, entity.PriorityImpacts(criteriaData.Leaves), functions)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	netFlows := make([]float64, len(flows))
+	for i := range flows {
+		ahpScores[i] = constant.RoundFloat(ahpScores[i]*100, 3)
+		netFlows[i] = constant.RoundFloat(flows[i].Net, 6)
+	}
+
+	ahpRanks, err := ahp.Ranks(ahpScores, collection.TiePolicy)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+	prometheeRanks, err := ahp.Ranks(netFlows, collection.TiePolicy)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	result := &dto.ComparisonResponse{
+		CollectionID:        payload.CollectionID,
+		Method:              criteriaData.Method,
+		Synthesis:           synthesis,
+		PreferenceFunctions: preferenceFunctions,
+		Alternatives:        make([]dto.ComparisonAlternative, 0),
+		AHPRanking:          alternativeRanking(alternatives, ahpScores),
+		PrometheeRanking:    alternativeRanking(alternatives, netFlows),
+	}
+	for i, alternative := range alternatives {
+		result.Alternatives = append(result.Alternatives, dto.ComparisonAlternative{
+			ID:            alternative.ID,
+			Nama:          alternative.Nama,
+			AHPScore:      ahpScores[i],
+			AHPRank:       ahpRanks[i],
+			PositiveFlow:  constant.RoundFloat(flows[i].Positive, 6),
+			NegativeFlow:  constant.RoundFloat(flows[i].Negative, 6),
+			NetFlow:       netFlows[i],
+			PrometheeRank: prometheeRanks[i],
+		})
+	}
+
+	//"AHP MEMILIH X, PROMETHEE MEMILIH Y"
+	result.AHPWinner = result.AHPRanking[0]
+	result.PrometheeWinner = result.PrometheeRanking[0]
+	result.SameWinner = result.AHPWinner == result.PrometheeWinner
+	result.SameRanking = true
+	for i := range result.AHPRanking {
+		if result.AHPRanking[i] != result.PrometheeRanking[i] {
+			result.SameRanking = false
+		}
+	}
+
+	return result, nil
+}
+
+func (s *service) CalculateSMAAByCollectionID(ctx context.Context, payload *dto.SMAARequest) (*dto.SMAAResponse, error) {
+	collection, err := s.CollectionRepository.FindByID(ctx, &payload.CollectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	method, synthesis := payload.Method, payload.Synthesis
+	if method == "" {
+		method = collection.WeightMethod
+	}
+	if synthesis == "" {
+		synthesis = collection.Synthesis
+	}
+
+	samples, sampling := payload.Samples, payload.Sampling
+	if samples == 0 {
+		samples = 2000
+	}
+	if sampling == "" {
+		sampling = ahp.SamplingDirichlet
+	}
+
+	//SEED ACAK HANYA JIKA TIDAK DIKIRIM, 0 JUGA SEED YANG SAH
+	seed := time.Now().UnixNano()
+	if payload.Seed != nil {
+		seed = *payload.Seed
+	}
+
+	alternatives, err := s.Repository.FindAlternativesByCollectionID(ctx, &payload.CollectionID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if len(alternatives) < 2 {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			"SMAA needs at least 2 alternatives")
+	}
+
+	criterias, matrices, template, err := s.findCriteriaMatrices(ctx, &payload.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := s.findCriteriaDependencies(ctx, &payload.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteriaData, err := networkWeights(criterias, matrices, template, dependencies, method)
+	if err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	matrix, ideals, err := s.alternativeMatrix(ctx, collection, alternatives, criteriaData.Leaves)
+	if err != nil {
+		return nil, err
+	}
+	synthesized, err := ahp.Synthesize(matrix, synthesis, ideals)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	result := &dto.SMAAResponse{
+		CollectionID: payload.CollectionID,
+		Method:       criteriaData.Method,
+		Synthesis:    synthesis,
+		Sampling:     sampling,
+		Samples:      samples,
+		Seed:         seed,
+		Codes:        criteriaData.Codes,
+		Weights:      criteriaData.Weights,
+		Alternatives: make([]dto.SMAAAlternative, 0),
+	}
+
+	var sampler ahp.WeightSampler
+	switch sampling {
+	case ahp.SamplingDirichlet:
+		result.Concentration = payload.Concentration
+		if result.Concentration == 0 {
+			result.Concentration = 100
+		}
+		sampler = ahp.DirichletSampler(criteriaData.Weights, result.Concentration)
+	case ahp.SamplingPerturbation:
+		result.Spread = payload.Spread
+		if result.Spread == 0 {
+			result.Spread = 0.25
+		}
+		sampler = perturbationSampler(criterias, matrices, template, dependencies, method, result.Spread)
+	}
+
+	impacts := entity.PriorityImpacts(criteriaData.Leaves)
+	score := func(weights []float64) ([]float64, error) {
+		return weightedScores(synthesized, weights, impacts, synthesis)
+	}
+
+	smaa, err := ahp.SMAA(ctx, len(alternatives), samples, payload.Workers, seed, sampler, score)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	for i, alternative := range alternatives {
+		acceptability := make([]float64, len(smaa.Acceptability[i]))
+		for r, b := range smaa.Acceptability[i] {
+			acceptability[r] = constant.RoundFloat(b, 4)
+		}
+		centralWeights := make([]float64, len(smaa.CentralWeights[i]))
+		for j, w := range smaa.CentralWeights[i] {
+			centralWeights[j] = constant.RoundFloat(w, 4)
+		}
+
+		result.Alternatives = append(result.Alternatives, dto.SMAAAlternative{
+			ID:             alternative.ID,
+			Nama:           alternative.Nama,
+			Acceptability:  acceptability,
+			CentralWeights: centralWeights,
+		})
+	}
+
+	return result, nil
+}
+
+// perturbationSampler perturbs every pairwise matrix of the hierarchy, the template and the criteria
+// dependencies included, and recomputes the global weights of the leaf criteria. A fuzzy matrix is perturbed
+// as a fuzzy matrix so the fuzzy methods still see its spread.
+func perturbationSampler(criterias []entity.CriteriaEntityModel, matrices map[string]entity.CriteriaMatrixEntity, template entity.Matrix, dependencies []entity.CriteriaDependencyEntityModel, method string, spread float64) ahp.WeightSampler {
+	nodeIDs := make([]string, 0)
+	for nodeID := range matrices {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	return func(rng *rand.Rand) ([]float64, error) {
+		//URUTAN NODE TETAP AGAR SAMPEL SAMA UNTUK SEED YANG SAMA
+		perturbed := make(map[string]entity.CriteriaMatrixEntity)
+		for _, nodeID := range nodeIDs {
+			criteriaMatrix := matrices[nodeID]
+			if criteriaMatrix.FuzzyPairwise != nil {
+				fuzzyPairwise := ahp.PerturbFuzzyMatrix(rng, criteriaMatrix.FuzzyPairwise, spread)
+				perturbed[nodeID] = entity.CriteriaMatrixEntity{Pairwise: ahp.MiddleMatrix(fuzzyPairwise), FuzzyPairwise: fuzzyPairwise}
+				continue
+			}
+			perturbed[nodeID] = entity.CriteriaMatrixEntity{Pairwise: ahp.PerturbMatrix(rng, criteriaMatrix.Pairwise, spread)}
+		}
+		perturbedTemplate := ahp.PerturbMatrix(rng, template, spread)
+
+		var perturbedDependencies []entity.CriteriaDependencyEntityModel
+		if dependencies != nil {
+			perturbedDependencies = make([]entity.CriteriaDependencyEntityModel, len(dependencies))
+			for i, dependency := range dependencies {
+				dependency.Pairwise = ahp.PerturbMatrix(rng, dependency.Pairwise, spread)
+				perturbedDependencies[i] = dependency
+			}
+		}
+
+		criteriaWeights, err := networkWeights(criterias, perturbed, perturbedTemplate, perturbedDependencies, method)
+		if err != nil {
+			return nil, err
+		}
+		return criteriaWeights.Weights, nil
+	}
+}
+
+// synthesizedScores scores the given alternatives by the weighted sum of their synthesized local priorities,
+// or by the TOPSIS closeness coefficient.
+func (s *service) synthesizedScores(ctx context.Context, collection *entity.CollectionEntityModel, alternatives []entity.AlternativeEntityModel, criteriaData *entity.CriteriaWeights, synthesis string) ([]float64, error) {
+	matrix, ideals, err := s.alternativeMatrix(ctx, collection, alternatives, criteriaData.Leaves)
+	if err != nil {
+		return nil, err
+	}
+
+	synthesized, err := ahp.Synthesize(matrix, synthesis, ideals)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	scores, err := weightedScores(synthesized, criteriaData.Weights, entity.PriorityImpacts(criteriaData.Leaves), synthesis)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+	}
+	return scores, nil
+}
+
+func weightedScores(synthesized [][]float64, weights []float64, impacts []string, synthesis string) ([]float64, error) {
+	if synthesis != ahp.SynthesisTopsis {
+		return ahp.Scores(synthesized, weights), nil
+	}
+
+	results, err := ahp.Topsis(synthesized, weights, impacts)
+	if err != nil {
+		return nil, err
+	}
+	closeness := make([]float64, len(results))
+	for i, result := range results {
+		closeness[i] = result.Closeness
+	}
+	return closeness, nil
+}
+
+func alternativeRanking(alternatives []entity.AlternativeEntityModel, scores []float64) []string {
+	ids := make([]string, 0)
+	for _, i := range ahp.Ranking(scores) {
+		ids = append(ids, alternatives[i].ID)
+	}
+	return ids
+}
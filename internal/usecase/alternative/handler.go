@@ -111,7 +111,7 @@ func (h *handler) GetByID(c echo.Context) error {
 
 // Create godoc
 // @Summary Create Alternative
-// @Description Create Alternative
+// @Description Create Alternative, values hold the sub criteria code per criteria code, see GET /ahp/subcriteria, raw_values hold measurements binned into the sub criteria by their [min, max) range, an edge belongs to the higher range, a measurement must lie in its physical range (no negative distance or count, no percentage above 100). Unknown criteria codes, out of range measurements and values that are not a sub criteria code of their criteria (listing the allowed codes) are all reported as field errors. The stored alternative pairwise matrices of the collection are resized, the new alternative compares equally with the others until they are resubmitted
 // @Tags alternative
 // @Accept  json
 // @Produce  json
@@ -144,7 +144,7 @@ func (h *handler) Create(c echo.Context) error {
 
 // Update godoc
 // @Summary Update alternative
-// @Description Update alternative
+// @Description Update alternative, raw_values are binned into the sub criteria by their [min, max) range after the physical range check and kept for later rebinning. Values are checked against the sub criteria codes like on create
 // @Tags alternative
 // @Accept  json
 // @Produce  json
@@ -175,7 +175,7 @@ func (h *handler) Update(c echo.Context) error {
 
 // Delete godoc
 // @Summary Delete alternative
-// @Description Delete alternative
+// @Description Delete alternative, its row and column are dropped from the stored alternative pairwise matrices of the collection
 // @Tags alternative
 // @Accept  json
 // @Produce  json
@@ -3,14 +3,19 @@ package alternative
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"net/http"
+	"sort"
+	"strings"
 	"ta13-svc/internal/abstraction"
 	dto "ta13-svc/internal/dto/alternative"
 	"ta13-svc/internal/entity"
 	"ta13-svc/internal/factory"
 	"ta13-svc/internal/repository"
 	"ta13-svc/pkg/response"
+	"ta13-svc/pkg/utils/ahp"
 	"ta13-svc/pkg/utils/trxmanager"
 )
 
@@ -96,7 +101,12 @@ func (s *service) Create(ctx context.Context, payload *dto.AlternativeCreateRequ
 			CollectionID:      payload.CollectionID,
 		}
 
-		_, err := alternativeRepository.FindByCollectionID(ctx, &payload.CollectionID)
+		values, err := buildValues(ctx, f, data.ID, payload.Values, payload.RawValues)
+		if err != nil {
+			return err
+		}
+
+		_, err = alternativeRepository.FindByCollectionID(ctx, &payload.CollectionID)
 		if err != nil {
 			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
 		}
@@ -106,7 +116,12 @@ func (s *service) Create(ctx context.Context, payload *dto.AlternativeCreateRequ
 			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
 		}
 
-		return nil
+		data.Values, err = alternativeRepository.ReplaceValues(ctx, &data.ID, values)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		return resizeAlternativeMatrices(ctx, f, payload.CollectionID)
 	}); err != nil {
 		return result, err
 
@@ -140,6 +155,18 @@ func (s *service) Update(ctx context.Context, payload *dto.AlternativeUpdateRequ
 		if err != nil {
 			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
 		}
+
+		if payload.Values != nil || payload.RawValues != nil {
+			values, err := buildValues(ctx, f, payload.ID, payload.Values, payload.RawValues)
+			if err != nil {
+				return err
+			}
+
+			data.Values, err = alternativeRepository.ReplaceValues(ctx, &payload.ID, values)
+			if err != nil {
+				return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+			}
+		}
 		return nil
 	}); err != nil {
 		return result, err
@@ -161,7 +188,7 @@ func (s *service) Delete(ctx context.Context, payload *dto.AlternativeDeleteRequ
 		data = &entity.AlternativeEntityModel{
 			AlternativeEntity: payload.AlternativeEntity,
 		}
-		_, err := alternativeRepository.FindByID(ctx, &payload.ID)
+		alternative, err := alternativeRepository.FindByID(ctx, &payload.ID)
 		if err != nil {
 			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
 		}
@@ -170,7 +197,8 @@ func (s *service) Delete(ctx context.Context, payload *dto.AlternativeDeleteRequ
 		if err != nil {
 			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
 		}
-		return nil
+
+		return resizeAlternativeMatrices(ctx, f, alternative.CollectionID)
 	}); err != nil {
 		return result, err
 	}
@@ -181,3 +209,134 @@ func (s *service) Delete(ctx context.Context, payload *dto.AlternativeDeleteRequ
 
 	return result, nil
 }
+
+// resizeAlternativeMatrices fits the stored alternative pairwise matrices of the collection to its current
+// alternatives, judgments between the remaining alternatives are kept.
+func resizeAlternativeMatrices(ctx context.Context, f *factory.Factory, collectionID string) error {
+	alternatives, err := f.AlternativeRepository.FindByCollectionID(ctx, &collectionID)
+	if err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	matrices, err := f.AlternativeMatrixRepository.FindByCollectionID(ctx, &collectionID)
+	if err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	alternativeIDs := entity.SortedAlternativeIDs(alternatives)
+	for _, matrix := range matrices {
+		matrix.AlternativeMatrixEntity = matrix.AlternativeMatrixEntity.Remap(alternativeIDs)
+		if _, err = f.AlternativeMatrixRepository.Update(ctx, &matrix.ID, &matrix); err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+	}
+
+	return nil
+}
+
+// buildValues keys the values by criteria code, every value must be the code of a sub criteria of its criteria.
+// A raw value is binned into the code of its sub criteria and kept next to it. Every invalid entry of the payload
+// is reported at once as a field error.
+func buildValues(ctx context.Context, f *factory.Factory, alternativeID string, values map[string]string, rawValues map[string]float64) ([]entity.AlternativeValueEntityModel, error) {
+	criterias, err := f.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criteriaByCode := make(map[string]entity.CriteriaEntityModel)
+	for _, criteria := range criterias {
+		criteriaByCode[criteria.Code] = criteria
+	}
+
+	//SUB KRITERIA DIMUAT SEKALI DAN DIKELOMPOKKAN PER KRITERIA
+	allSubCriterias, err := f.SubCriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	subCriteriasByCriteria := make(map[string][]entity.SubCriteriaEntityModel)
+	for _, subCriteria := range allSubCriterias {
+		subCriteriasByCriteria[subCriteria.CriteriaID] = append(subCriteriasByCriteria[subCriteria.CriteriaID], subCriteria)
+	}
+
+	codes := make([]string, 0)
+	for code := range values {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	datas := make([]entity.AlternativeValueEntityModel, 0)
+	fieldErrors := make([]response.FieldError, 0)
+	for _, code := range codes {
+		value := values[code]
+		criteria, ok := criteriaByCode[code]
+		if !ok {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: "values." + code, Message: fmt.Sprintf("unknown criteria code %s", code)})
+			continue
+		}
+		if _, ok = rawValues[code]; ok {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: "values." + code, Message: "criteria has both a value and a raw value, send only one"})
+			continue
+		}
+
+		//NILAI HARUS SALAH SATU SUB KRITERIA DARI KRITERIANYA
+		if err = entity.ValidateSubCriteriaValue(subCriteriasByCriteria[criteria.ID], value); err != nil {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: "values." + code, Message: err.Error()})
+			continue
+		}
+
+		datas = append(datas, entity.AlternativeValueEntityModel{
+			Entity:                 abstraction.Entity{ID: uuid.NewString()},
+			AlternativeValueEntity: entity.AlternativeValueEntity{Value: value},
+			AlternativeID:          alternativeID,
+			CriteriaID:             criteria.ID,
+			Criteria:               criteria,
+		})
+	}
+
+	rawCodes := make([]string, 0)
+	for code := range rawValues {
+		rawCodes = append(rawCodes, code)
+	}
+	sort.Strings(rawCodes)
+
+	unbinned := make([]string, 0)
+	for _, code := range rawCodes {
+		raw := rawValues[code]
+		criteria, ok := criteriaByCode[code]
+		if !ok {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: "raw_values." + code, Message: fmt.Sprintf("unknown criteria code %s", code)})
+			continue
+		}
+
+		//NILAI MENTAH HARUS DALAM RENTANG FISIKNYA (JARAK >= 0, PERSENTASE <= 100)
+		if err = ahp.DefaultSubCriteria()[code].Domain.Check(raw); err != nil {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: "raw_values." + code, Message: err.Error()})
+			continue
+		}
+
+		//NILAI MENTAH DIPETAKAN KE SUB KRITERIA SESUAI RENTANGNYA
+		subCriteriaCode, err := entity.ResolveBin(subCriteriasByCriteria[criteria.ID], raw)
+		if err != nil {
+			unbinned = append(unbinned, fmt.Sprintf("Criteria %s: %s", code, err.Error()))
+			continue
+		}
+
+		datas = append(datas, entity.AlternativeValueEntityModel{
+			Entity:                 abstraction.Entity{ID: uuid.NewString()},
+			AlternativeValueEntity: entity.AlternativeValueEntity{Value: subCriteriaCode, Raw: &raw},
+			AlternativeID:          alternativeID,
+			CriteriaID:             criteria.ID,
+			Criteria:               criteria,
+		})
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, response.FieldErrorBuilder(fieldErrors)
+	}
+	if len(unbinned) > 0 {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, strings.Join(unbinned, ", "))
+	}
+
+	return datas, nil
+}
@@ -109,7 +109,7 @@ func (h *handler) GetByUserID(c echo.Context) error {
 
 // Create godoc
 // @Summary Create collection
-// @Description Create collection
+// @Description Create collection, score_is_calculated, final_score_is_calculated and weight_iterations are set by the server and ignored in the body
 // @Tags collection
 // @Accept  json
 // @Produce  json
@@ -141,7 +141,7 @@ func (h *handler) Create(c echo.Context) error {
 
 // Update godoc
 // @Summary Update collection
-// @Description Update collection
+// @Description Update collection, score_is_calculated, final_score_is_calculated and weight_iterations are set by the server and ignored in the body
 // @Tags collection
 // @Accept  json
 // @Produce  json
@@ -200,3 +200,460 @@ func (h *handler) Delete(c echo.Context) error {
 
 	return response.SuccessResponse(result).Send(c)
 }
+
+// GetCriteria
+// @Summary Get Criteria Pairwise Matrix By CollectionID
+// @Description Get Criteria Pairwise Matrix By CollectionID, falls back to the default template for the goal, a node without judgments compares its children equally
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param id path string true "id path"
+// @Param node_id query string false "criteria id of the hierarchy node, empty for the goal"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param scale query string false "linguistic scale, defaults to the scale the matrix was judged on" Enums(saaty, balanced, geometric)
+// @Success 200 {object} dto.CollectionCriteriaResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/criteria [get]
+func (h *handler) GetCriteria(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload := new(dto.CollectionCriteriaGetRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindCriteria(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// UpdateCriteria godoc
+// @Summary Update Criteria Pairwise Matrix By CollectionID
+// @Description Update Criteria Pairwise Matrix By CollectionID, or by the best worst method (best_worst) whose weights are used as the local priorities of the node, rejected when the consistency ratio exceeds 0.1 unless force is set
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param request body dto.CollectionCriteriaUpdateRequest true "request body"
+// @Success 200 {object} dto.CollectionCriteriaResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/criteria [put]
+func (h *handler) UpdateCriteria(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionCriteriaUpdateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.UpdateCriteria(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetJudgments
+// @Summary Get Expert Judgments By CollectionID
+// @Description Get every expert pairwise matrix of a collection node with the individual and aggregated consistency ratio
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param id path string true "id path"
+// @Param node_id query string false "criteria id of the hierarchy node, empty for the goal"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Param aggregation query string false "aggregation of individual judgments or priorities" Enums(aij, aip)
+// @Success 200 {object} dto.CollectionJudgmentsResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/judgments [get]
+func (h *handler) GetJudgments(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload := new(dto.CollectionJudgmentsGetRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindJudgments(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// UpdateJudgment godoc
+// @Summary Submit Expert Judgment By CollectionID
+// @Description Submit or replace the pairwise matrix of one expert, rejected when the consistency ratio exceeds 0.1 unless force is set, the user has to exist
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param user_id path string true "user_id path"
+// @Param request body dto.CollectionJudgmentUpdateRequest true "request body"
+// @Success 200 {object} dto.CollectionJudgmentResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/judgments/{user_id} [put]
+func (h *handler) UpdateJudgment(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionJudgmentUpdateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.UpdateJudgment(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// DeleteJudgment godoc
+// @Summary Delete Expert Judgment By CollectionID
+// @Description Delete the pairwise matrix of one expert
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param user_id path string true "user_id path"
+// @Param node_id query string false "criteria id of the hierarchy node, empty for the goal"
+// @Success 200 {object} dto.CollectionJudgmentDeleteResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/judgments/{user_id} [delete]
+func (h *handler) DeleteJudgment(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionJudgmentDeleteRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.DeleteJudgment(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// AggregateJudgments godoc
+// @Summary Aggregate Expert Judgments By CollectionID
+// @Description Aggregate the expert judgments by AIJ or AIP and store the result as the criteria matrix of the collection node
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param request body dto.CollectionJudgmentsAggregateRequest true "request body"
+// @Success 200 {object} dto.CollectionJudgmentsResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/judgments/aggregate [post]
+func (h *handler) AggregateJudgments(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionJudgmentsAggregateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.AggregateJudgments(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetAlternativeMatrices
+// @Summary Get Alternative Pairwise Matrices By CollectionID
+// @Description Get the pairwise comparison of the alternatives on every leaf criteria with their local priorities and consistency ratio, used when the scoring mode of the collection is pairwise
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param id path string true "id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Success 200 {object} dto.CollectionAlternativeMatricesResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/alternative_matrices [get]
+func (h *handler) GetAlternativeMatrices(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload := new(dto.CollectionAlternativeMatricesGetRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindAlternativeMatrices(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// UpdateAlternativeMatrix godoc
+// @Summary Update Alternative Pairwise Matrix By CollectionID and CriteriaID
+// @Description Compare the alternatives of a collection on one leaf criteria, rejected when the consistency ratio exceeds 0.1 unless force is set
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param criteria_id path string true "criteria_id path"
+// @Param request body dto.CollectionAlternativeMatrixUpdateRequest true "request body"
+// @Success 200 {object} dto.CollectionAlternativeMatrixResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/alternative_matrices/{criteria_id} [put]
+func (h *handler) UpdateAlternativeMatrix(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionAlternativeMatrixUpdateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.UpdateAlternativeMatrix(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetDependencies godoc
+// @Summary Get Criteria Dependencies By CollectionID
+// @Description Get the inner and outer dependencies of the criteria network with their local priorities, used when the decision model of the collection is anp
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param id path string true "id path"
+// @Param method query string false "weighting method" Enums(average, eigenvector, geometric_mean, fuzzy_extent, fuzzy_geometric_mean)
+// @Success 200 {object} dto.CollectionDependenciesResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/dependencies [get]
+func (h *handler) GetDependencies(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload := new(dto.CollectionDependenciesGetRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindDependencies(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// UpdateDependency godoc
+// @Summary Update Criteria Dependency By CollectionID and CriteriaID
+// @Description Compare the criteria of a cluster on their influence on the control criteria, the cluster of the control criteria itself is an inner dependency, any other cluster an outer one
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param criteria_id path string true "control criteria_id path"
+// @Param request body dto.CollectionDependencyUpdateRequest true "request body"
+// @Success 200 {object} dto.CollectionDependencyResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/dependencies/{criteria_id} [put]
+func (h *handler) UpdateDependency(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionDependencyUpdateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.UpdateDependency(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// DeleteDependency godoc
+// @Summary Delete Criteria Dependency By CollectionID and CriteriaID
+// @Description Delete one dependency of the control criteria, a criteria without dependencies keeps the hierarchy weights in its supermatrix column
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param criteria_id path string true "control criteria_id path"
+// @Param cluster_id query string false "criteria id of the cluster, empty for the goal"
+// @Success 200 {object} dto.CollectionDependencyDeleteResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/dependencies/{criteria_id} [delete]
+func (h *handler) DeleteDependency(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionDependencyDeleteRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.DeleteDependency(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetValueFunctions godoc
+// @Summary Get Value Functions By CollectionID
+// @Description Get the value functions that score the raw values of the alternatives, with a preview of 11 points over their domain
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param id path string true "id path"
+// @Success 200 {object} dto.CollectionValueFunctionsResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/value_functions [get]
+func (h *handler) GetValueFunctions(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload := new(dto.CollectionValueFunctionsGetRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindValueFunctions(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// UpdateValueFunction godoc
+// @Summary Update Value Function By CollectionID and CriteriaID
+// @Description Score the raw value of a leaf criteria with a linear, piecewise_linear, exponential or step value function instead of its sub criteria, linear and exponential use min and max (rho bends the exponential curve), piecewise_linear and step use the points. The 0..1 score is multiplied by the weight of the best sub criteria so it is on the same scale as rated criteria
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param criteria_id path string true "leaf criteria_id path"
+// @Param request body dto.CollectionValueFunctionUpdateRequest true "request body"
+// @Success 200 {object} dto.CollectionValueFunctionResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/value_functions/{criteria_id} [put]
+func (h *handler) UpdateValueFunction(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionValueFunctionUpdateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.UpdateValueFunction(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// DeleteValueFunction godoc
+// @Summary Delete Value Function By CollectionID and CriteriaID
+// @Description Delete the value function of the criteria, its alternatives are scored by their sub criteria again
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param criteria_id path string true "leaf criteria_id path"
+// @Success 200 {object} dto.CollectionValueFunctionDeleteResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /collection/{id}/value_functions/{criteria_id} [delete]
+func (h *handler) DeleteValueFunction(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CollectionValueFunctionDeleteRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.DeleteValueFunction(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
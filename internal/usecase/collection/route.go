@@ -23,4 +23,18 @@ func (h *handler) Route(g *echo.Group) {
 	g.POST("", h.Create)
 	g.PATCH("", h.Update)
 	g.DELETE("/:id", h.Delete)
+	g.GET("/:id/criteria", h.GetCriteria)
+	g.PUT("/:id/criteria", h.UpdateCriteria)
+	g.GET("/:id/judgments", h.GetJudgments)
+	g.POST("/:id/judgments/aggregate", h.AggregateJudgments)
+	g.PUT("/:id/judgments/:user_id", h.UpdateJudgment)
+	g.DELETE("/:id/judgments/:user_id", h.DeleteJudgment)
+	g.GET("/:id/alternative_matrices", h.GetAlternativeMatrices)
+	g.PUT("/:id/alternative_matrices/:criteria_id", h.UpdateAlternativeMatrix)
+	g.GET("/:id/dependencies", h.GetDependencies)
+	g.PUT("/:id/dependencies/:criteria_id", h.UpdateDependency)
+	g.DELETE("/:id/dependencies/:criteria_id", h.DeleteDependency)
+	g.GET("/:id/value_functions", h.GetValueFunctions)
+	g.PUT("/:id/value_functions/:criteria_id", h.UpdateValueFunction)
+	g.DELETE("/:id/value_functions/:criteria_id", h.DeleteValueFunction)
 }
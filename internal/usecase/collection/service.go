@@ -3,14 +3,18 @@ package collection
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"net/http"
 	"ta13-svc/internal/abstraction"
 	dto "ta13-svc/internal/dto/collection"
 	"ta13-svc/internal/entity"
 	"ta13-svc/internal/factory"
 	"ta13-svc/internal/repository"
 	"ta13-svc/pkg/response"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/constant"
 	"ta13-svc/pkg/utils/trxmanager"
 )
 
@@ -21,17 +25,45 @@ type Service interface {
 	Create(ctx context.Context, payload *dto.CollectionCreateRequest) (*dto.CollectionCreateResponse, error)
 	Update(ctx context.Context, payload *dto.CollectionUpdateRequest) (*dto.CollectionUpdateResponse, error)
 	Delete(ctx context.Context, payload *dto.CollectionDeleteRequest) (*dto.CollectionDeleteResponse, error)
+	FindCriteria(ctx context.Context, payload *dto.CollectionCriteriaGetRequest) (*dto.CollectionCriteriaResponse, error)
+	UpdateCriteria(ctx context.Context, payload *dto.CollectionCriteriaUpdateRequest) (*dto.CollectionCriteriaResponse, error)
+	FindJudgments(ctx context.Context, payload *dto.CollectionJudgmentsGetRequest) (*dto.CollectionJudgmentsResponse, error)
+	UpdateJudgment(ctx context.Context, payload *dto.CollectionJudgmentUpdateRequest) (*dto.CollectionJudgmentResponse, error)
+	DeleteJudgment(ctx context.Context, payload *dto.CollectionJudgmentDeleteRequest) (*dto.CollectionJudgmentDeleteResponse, error)
+	AggregateJudgments(ctx context.Context, payload *dto.CollectionJudgmentsAggregateRequest) (*dto.CollectionJudgmentsResponse, error)
+	FindAlternativeMatrices(ctx context.Context, payload *dto.CollectionAlternativeMatricesGetRequest) (*dto.CollectionAlternativeMatricesResponse, error)
+	UpdateAlternativeMatrix(ctx context.Context, payload *dto.CollectionAlternativeMatrixUpdateRequest) (*dto.CollectionAlternativeMatrixResponse, error)
+	FindDependencies(ctx context.Context, payload *dto.CollectionDependenciesGetRequest) (*dto.CollectionDependenciesResponse, error)
+	UpdateDependency(ctx context.Context, payload *dto.CollectionDependencyUpdateRequest) (*dto.CollectionDependencyResponse, error)
+	DeleteDependency(ctx context.Context, payload *dto.CollectionDependencyDeleteRequest) (*dto.CollectionDependencyDeleteResponse, error)
+	FindValueFunctions(ctx context.Context, payload *dto.CollectionValueFunctionsGetRequest) (*dto.CollectionValueFunctionsResponse, error)
+	UpdateValueFunction(ctx context.Context, payload *dto.CollectionValueFunctionUpdateRequest) (*dto.CollectionValueFunctionResponse, error)
+	DeleteValueFunction(ctx context.Context, payload *dto.CollectionValueFunctionDeleteRequest) (*dto.CollectionValueFunctionDeleteResponse, error)
 }
 
 type service struct {
-	Repository repository.CollectionRepository
-	Db         *gorm.DB
+	Repository                   repository.CollectionRepository
+	CriteriaMatrixRepository     repository.CriteriaMatrixRepository
+	CriteriaRepository           repository.CriteriaRepository
+	ExpertJudgmentRepository     repository.ExpertJudgmentRepository
+	AlternativeRepository        repository.AlternativeRepository
+	AlternativeMatrixRepository  repository.AlternativeMatrixRepository
+	CriteriaDependencyRepository repository.CriteriaDependencyRepository
+	ValueFunctionRepository      repository.ValueFunctionRepository
+	Db                           *gorm.DB
 }
 
 func NewService(f *factory.Factory) *service {
 	repository := f.CollectionRepository
+	criteriaMatrixRepository := f.CriteriaMatrixRepository
+	criteriaRepository := f.CriteriaRepository
+	expertJudgmentRepository := f.ExpertJudgmentRepository
+	alternativeRepository := f.AlternativeRepository
+	alternativeMatrixRepository := f.AlternativeMatrixRepository
+	criteriaDependencyRepository := f.CriteriaDependencyRepository
+	valueFunctionRepository := f.ValueFunctionRepository
 	db := f.Db
-	return &service{repository, db}
+	return &service{repository, criteriaMatrixRepository, criteriaRepository, expertJudgmentRepository, alternativeRepository, alternativeMatrixRepository, criteriaDependencyRepository, valueFunctionRepository, db}
 }
 
 func (s *service) FindAll(ctx context.Context) ([]entity.CollectionEntityModel, error) {
@@ -89,13 +121,40 @@ func (s *service) Create(ctx context.Context, payload *dto.CollectionCreateReque
 
 	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
 		collectionRepository := f.CollectionRepository
-		data = &entity.CollectionEntityModel{CollectionEntity: payload.CollectionEntity, UserID: uuID, Entity: abstraction.Entity{
+		data = &entity.CollectionEntityModel{CollectionEntity: payload.CollectionEntity.Settings(), UserID: uuID, Entity: abstraction.Entity{
 			ID: uuid.NewString(),
 		}}
 		_, err := collectionRepository.Create(ctx, data)
 		if err != nil {
 			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
 		}
+
+		template, err := f.CriteriaMatrixRepository.FindDefault(ctx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+
+		criterias, err := f.CriteriaRepository.FindAll(ctx)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+
+		//TEMPLATE HANYA DISALIN JIKA SESUAI DENGAN KRITERIA DI BAWAH GOAL
+		if len(template.Pairwise) != len(entity.ChildCriterias(criterias, entity.GoalNodeID)) {
+			return nil
+		}
+
+		_, err = f.CriteriaMatrixRepository.Upsert(ctx, &entity.CriteriaMatrixEntityModel{
+			Entity:               abstraction.Entity{ID: uuid.NewString()},
+			CriteriaMatrixEntity: template.CriteriaMatrixEntity,
+			CollectionID:         &data.ID,
+		})
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
 		return nil
 	}); err != nil {
 		return result, err
@@ -118,7 +177,7 @@ func (s *service) Update(ctx context.Context, payload *dto.CollectionUpdateReque
 
 		data = &entity.CollectionEntityModel{
 			Entity:           abstraction.Entity{ID: payload.ID},
-			CollectionEntity: payload.CollectionEntity,
+			CollectionEntity: payload.CollectionEntity.Settings(),
 		}
 		_, err := collectionRepository.FindByID(ctx, &payload.ID)
 		if err != nil {
@@ -174,3 +233,886 @@ func (s *service) Delete(ctx context.Context, payload *dto.CollectionDeleteReque
 
 	return result, nil
 }
+
+func (s *service) FindCriteria(ctx context.Context, payload *dto.CollectionCriteriaGetRequest) (*dto.CollectionCriteriaResponse, error) {
+	var result *dto.CollectionCriteriaResponse
+
+	_, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	children, err := s.findNodeChildren(ctx, payload.NodeID)
+	if err != nil {
+		return result, err
+	}
+
+	isDefault := false
+	var criteriaMatrixEntity entity.CriteriaMatrixEntity
+
+	criteriaMatrix, err := s.CriteriaMatrixRepository.FindByCollectionNodeID(ctx, &payload.ID, payload.NodeID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+
+		//GOAL MENGGUNAKAN TEMPLATE, NODE LAIN DIBOBOT RATA
+		isDefault = true
+		if payload.NodeID == entity.GoalNodeID {
+			template, err := s.CriteriaMatrixRepository.FindDefault(ctx)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+			}
+			if template != nil {
+				criteriaMatrixEntity = template.CriteriaMatrixEntity
+			}
+		}
+		criteriaMatrixEntity = entity.DefaultCriteriaMatrix(criteriaMatrixEntity, len(children))
+	} else {
+		criteriaMatrixEntity = criteriaMatrix.CriteriaMatrixEntity
+	}
+
+	criteriaData, err := s.newCriteriaData(criteriaMatrixEntity, payload.Method, children)
+	if err != nil {
+		return result, err
+	}
+
+	//TANPA PARAMETER, SKALA YANG DIPAKAI SAAT PENILAIAN
+	scale := payload.Scale
+	if scale == "" {
+		scale = criteriaMatrixEntity.Scale
+	}
+	if err = criteriaData.SetLinguistic(scale); err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.BadRequest, err)
+	}
+
+	result = &dto.CollectionCriteriaResponse{
+		CriteriaData: *criteriaData,
+		CollectionID: payload.ID,
+		NodeID:       payload.NodeID,
+		IsDefault:    isDefault,
+	}
+
+	return result, nil
+}
+
+func (s *service) UpdateCriteria(ctx context.Context, payload *dto.CollectionCriteriaUpdateRequest) (*dto.CollectionCriteriaResponse, error) {
+	var result *dto.CollectionCriteriaResponse
+
+	children, err := s.findNodeChildren(ctx, payload.NodeID)
+	if err != nil {
+		return result, err
+	}
+
+	var criteriaMatrixEntity entity.CriteriaMatrixEntity
+	var estimated []ahp.MatrixCell
+
+	switch {
+	case payload.BestWorst != nil:
+		//PENILAIAN BWM DISIMPAN BERSAMA MATRIKS KONSISTEN DARI BOBOTNYA
+		if errs := payload.BestWorst.Validate("best_worst", len(children)); len(errs) > 0 {
+			return result, response.InputErrorBuilder(errs)
+		}
+		bestWorst, err := entity.NewBestWorstCriteriaData(*payload.BestWorst)
+		if err != nil {
+			return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+		}
+		criteriaMatrixEntity.BestWorst = payload.BestWorst
+		criteriaMatrixEntity.Pairwise = bestWorst.PairwiseFromJson
+	case payload.FuzzyPairwise != nil:
+		//PENILAIAN FUZZY DISIMPAN BERSAMA NILAI TENGAHNYA SEBAGAI MATRIKS CRISP
+		if !payload.FuzzyPairwise.IsSquare() {
+			return result, response.ErrorBuilder(&response.ErrorConstant.Validation, errors.New("fuzzy pairwise matrix must be square"))
+		}
+		criteriaMatrixEntity.FuzzyPairwise = payload.FuzzyPairwise
+		criteriaMatrixEntity.Pairwise = ahp.MiddleMatrix(payload.FuzzyPairwise)
+	default:
+		criteriaMatrixEntity.Pairwise, estimated, err = buildPairwise(payload.PairwiseInput, payload.Completion, len(children))
+		if err != nil {
+			return result, err
+		}
+	}
+	criteriaMatrixEntity.Scale = payload.Scale
+
+	criteriaData, err := s.newCriteriaData(criteriaMatrixEntity, payload.Method, children)
+	if err != nil {
+		return result, err
+	}
+	setEstimated(criteriaData, payload.Completion, estimated)
+
+	if err = criteriaData.SetLinguistic(payload.Scale); err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.BadRequest, err)
+	}
+
+	if !criteriaData.IsConsistent && !payload.Force {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Pairwise matrix is", criteriaData.ConsistencyRatio))
+	}
+
+	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
+		_, err := f.CollectionRepository.FindByID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+
+		_, err = f.CriteriaMatrixRepository.Upsert(ctx, &entity.CriteriaMatrixEntityModel{
+			Entity:               abstraction.Entity{ID: uuid.NewString()},
+			CriteriaMatrixEntity: criteriaMatrixEntity,
+			CollectionID:         &payload.ID,
+			NodeID:               payload.NodeID,
+		})
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	result = &dto.CollectionCriteriaResponse{
+		CriteriaData: *criteriaData,
+		CollectionID: payload.ID,
+		NodeID:       payload.NodeID,
+	}
+
+	return result, nil
+}
+
+func (s *service) FindJudgments(ctx context.Context, payload *dto.CollectionJudgmentsGetRequest) (*dto.CollectionJudgmentsResponse, error) {
+	var result *dto.CollectionJudgmentsResponse
+
+	_, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	group, err := s.newGroupCriteriaData(ctx, payload.ID, payload.NodeID, payload.Aggregation, payload.Method)
+	if err != nil {
+		return result, err
+	}
+
+	result = &dto.CollectionJudgmentsResponse{
+		GroupCriteriaData: *group,
+		CollectionID:      payload.ID,
+		NodeID:            payload.NodeID,
+	}
+
+	return result, nil
+}
+
+func (s *service) UpdateJudgment(ctx context.Context, payload *dto.CollectionJudgmentUpdateRequest) (*dto.CollectionJudgmentResponse, error) {
+	var result *dto.CollectionJudgmentResponse
+
+	children, err := s.findNodeChildren(ctx, payload.NodeID)
+	if err != nil {
+		return result, err
+	}
+
+	pairwise, estimated, err := buildPairwise(payload.PairwiseInput, payload.Completion, len(children))
+	if err != nil {
+		return result, err
+	}
+
+	criteriaData, err := s.newCriteriaData(entity.CriteriaMatrixEntity{Pairwise: pairwise}, payload.Method, children)
+	if err != nil {
+		return result, err
+	}
+	setEstimated(criteriaData, payload.Completion, estimated)
+
+	if !criteriaData.IsConsistent && !payload.Force {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Pairwise matrix is", criteriaData.ConsistencyRatio))
+	}
+
+	//BOBOT PAKAR DEFAULT 1
+	if payload.Weight == 0 {
+		payload.Weight = 1
+	}
+
+	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
+		_, err := f.CollectionRepository.FindByID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+
+		_, err = f.UserRepository.FindByID(ctx, &payload.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return response.CustomErrorBuilder(http.StatusNotFound, response.E_NOT_FOUND, fmt.Sprintf("User %s not found", payload.UserID))
+			}
+			return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+
+		_, err = f.ExpertJudgmentRepository.Upsert(ctx, &entity.ExpertJudgmentEntityModel{
+			Entity: abstraction.Entity{ID: uuid.NewString()},
+			ExpertJudgmentEntity: entity.ExpertJudgmentEntity{
+				Pairwise: pairwise,
+				Weight:   payload.Weight,
+			},
+			CollectionID: payload.ID,
+			UserID:       payload.UserID,
+			NodeID:       payload.NodeID,
+		})
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	result = &dto.CollectionJudgmentResponse{
+		ExpertCriteriaData: entity.ExpertCriteriaData{
+			UserID:       payload.UserID,
+			Weight:       payload.Weight,
+			CriteriaData: *criteriaData,
+		},
+		CollectionID: payload.ID,
+		NodeID:       payload.NodeID,
+	}
+
+	return result, nil
+}
+
+func (s *service) DeleteJudgment(ctx context.Context, payload *dto.CollectionJudgmentDeleteRequest) (*dto.CollectionJudgmentDeleteResponse, error) {
+	var result *dto.CollectionJudgmentDeleteResponse
+
+	err := s.ExpertJudgmentRepository.Delete(ctx, &payload.ID, &payload.UserID, payload.NodeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	result = &dto.CollectionJudgmentDeleteResponse{
+		CollectionID: payload.ID,
+		UserID:       payload.UserID,
+		NodeID:       payload.NodeID,
+	}
+
+	return result, nil
+}
+
+func (s *service) AggregateJudgments(ctx context.Context, payload *dto.CollectionJudgmentsAggregateRequest) (*dto.CollectionJudgmentsResponse, error) {
+	var result *dto.CollectionJudgmentsResponse
+
+	group, err := s.newGroupCriteriaData(ctx, payload.ID, payload.NodeID, payload.Aggregation, payload.Method)
+	if err != nil {
+		return result, err
+	}
+
+	if !group.Aggregated.IsConsistent && !payload.Force {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Aggregated pairwise matrix is", group.Aggregated.ConsistencyRatio))
+	}
+
+	//MATRIKS AGREGAT MENJADI MATRIKS KRITERIA KOLEKSI
+	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
+		_, err := f.CollectionRepository.FindByID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+
+		_, err = f.CriteriaMatrixRepository.Upsert(ctx, &entity.CriteriaMatrixEntityModel{
+			Entity:               abstraction.Entity{ID: uuid.NewString()},
+			CriteriaMatrixEntity: entity.CriteriaMatrixEntity{Pairwise: group.Aggregated.PairwiseFromJson},
+			CollectionID:         &payload.ID,
+			NodeID:               payload.NodeID,
+		})
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	result = &dto.CollectionJudgmentsResponse{
+		GroupCriteriaData: *group,
+		CollectionID:      payload.ID,
+		NodeID:            payload.NodeID,
+	}
+
+	return result, nil
+}
+
+func (s *service) newGroupCriteriaData(ctx context.Context, collectionID string, nodeID string, aggregation string, method string) (*entity.GroupCriteriaData, error) {
+	children, err := s.findNodeChildren(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	judgments, err := s.ExpertJudgmentRepository.FindByCollectionNodeID(ctx, &collectionID, nodeID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	if len(judgments) == 0 {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, errors.New("no expert judgments have been submitted"))
+	}
+
+	group, err := entity.NewGroupCriteriaData(judgments, aggregation, method)
+	if err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	if err = group.SetCriteria(children); err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	return group, nil
+}
+
+func (s *service) FindAlternativeMatrices(ctx context.Context, payload *dto.CollectionAlternativeMatricesGetRequest) (*dto.CollectionAlternativeMatricesResponse, error) {
+	var result *dto.CollectionAlternativeMatricesResponse
+
+	collection, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	alternativeIDs, err := s.findAlternativeIDs(ctx, payload.ID)
+	if err != nil {
+		return result, err
+	}
+
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	alternativeMatrices, err := s.AlternativeMatrixRepository.FindByCollectionID(ctx, &payload.ID)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	matrixByCriteria := make(map[string]entity.AlternativeMatrixEntity)
+	for _, m := range alternativeMatrices {
+		matrixByCriteria[m.CriteriaID] = m.AlternativeMatrixEntity
+	}
+
+	method := payload.Method
+	if method == "" {
+		method = collection.WeightMethod
+	}
+
+	//KRITERIA TANPA MATRIKS DITAMPILKAN DENGAN PRIORITAS RATA
+	matrices := make([]dto.CollectionAlternativeMatrixResponse, 0)
+	for _, criteria := range entity.LeafCriterias(criterias) {
+		alternativeMatrix, ok := matrixByCriteria[criteria.ID]
+		if !ok {
+			alternativeMatrix = entity.NewEqualAlternativeMatrix(alternativeIDs)
+		}
+
+		criteriaData, err := alternativeMatrix.CriteriaData(alternativeIDs, method)
+		if err != nil {
+			return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+				fmt.Sprintf("Criteria %s: %s, resubmit the alternative comparisons", criteria.Code, err.Error()))
+		}
+
+		matrices = append(matrices, dto.CollectionAlternativeMatrixResponse{
+			CriteriaData:   *criteriaData,
+			CollectionID:   payload.ID,
+			CriteriaID:     criteria.ID,
+			CriteriaCode:   criteria.Code,
+			AlternativeIDs: alternativeIDs,
+			IsDefault:      !ok,
+		})
+	}
+
+	result = &dto.CollectionAlternativeMatricesResponse{
+		CollectionID: payload.ID,
+		ScoringMode:  collection.ScoringMode,
+		Matrices:     matrices,
+	}
+
+	return result, nil
+}
+
+func (s *service) UpdateAlternativeMatrix(ctx context.Context, payload *dto.CollectionAlternativeMatrixUpdateRequest) (*dto.CollectionAlternativeMatrixResponse, error) {
+	var result *dto.CollectionAlternativeMatrixResponse
+
+	_, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	var criteria *entity.CriteriaEntityModel
+	leaves := entity.LeafCriterias(criterias)
+	for i := range leaves {
+		if leaves[i].ID == payload.CriteriaID {
+			criteria = &leaves[i]
+		}
+	}
+	if criteria == nil {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			fmt.Sprintf("Criteria %s is not a leaf criteria, alternatives are only compared on leaf criteria", payload.CriteriaID))
+	}
+
+	alternativeIDs, err := s.findAlternativeIDs(ctx, payload.ID)
+	if err != nil {
+		return result, err
+	}
+
+	//URUTAN BARIS MATRIKS HARUS MEMUAT SEMUA ALTERNATIF KOLEKSI
+	order := alternativeIDs
+	if payload.AlternativeIDs != nil {
+		order = payload.AlternativeIDs
+		if !sameAlternatives(order, alternativeIDs) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.Validation,
+				errors.New("alternative_ids must list every alternative of the collection exactly once"))
+		}
+	}
+
+	pairwise, estimated, err := buildPairwise(payload.PairwiseInput, payload.Completion, len(order))
+	if err != nil {
+		return result, err
+	}
+
+	alternativeMatrix := entity.AlternativeMatrixEntity{
+		Pairwise:       pairwise,
+		AlternativeIDs: order,
+	}
+
+	criteriaData, err := alternativeMatrix.CriteriaData(order, payload.Method)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+	setEstimated(criteriaData, payload.Completion, estimated)
+
+	if !criteriaData.IsConsistent && !payload.Force {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Pairwise matrix is", criteriaData.ConsistencyRatio))
+	}
+
+	_, err = s.AlternativeMatrixRepository.Upsert(ctx, &entity.AlternativeMatrixEntityModel{
+		Entity:                  abstraction.Entity{ID: uuid.NewString()},
+		AlternativeMatrixEntity: alternativeMatrix,
+		CollectionID:            payload.ID,
+		CriteriaID:              payload.CriteriaID,
+	})
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+	}
+
+	result = &dto.CollectionAlternativeMatrixResponse{
+		CriteriaData:   *criteriaData,
+		CollectionID:   payload.ID,
+		CriteriaID:     criteria.ID,
+		CriteriaCode:   criteria.Code,
+		AlternativeIDs: order,
+	}
+
+	return result, nil
+}
+
+func (s *service) FindDependencies(ctx context.Context, payload *dto.CollectionDependenciesGetRequest) (*dto.CollectionDependenciesResponse, error) {
+	var result *dto.CollectionDependenciesResponse
+
+	collection, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criteriaDependencies, err := s.CriteriaDependencyRepository.FindByCollectionID(ctx, &payload.ID)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	method := payload.Method
+	if method == "" {
+		method = collection.WeightMethod
+	}
+
+	dependencies := make([]dto.CollectionDependencyResponse, 0)
+	for _, criteriaDependency := range criteriaDependencies {
+		control, err := findLeaf(criterias, criteriaDependency.CriteriaID)
+		if err != nil {
+			return result, err
+		}
+
+		criteriaData, err := dependencyCriteriaData(criterias, criteriaDependency.ClusterID, criteriaDependency.Pairwise, method)
+		if err != nil {
+			return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+				fmt.Sprintf("Dependency of criteria %s: %s, resubmit the comparisons", control.Code, err.Error()))
+		}
+
+		dependencies = append(dependencies, dto.CollectionDependencyResponse{
+			CriteriaData: *criteriaData,
+			CollectionID: payload.ID,
+			CriteriaID:   control.ID,
+			CriteriaCode: control.Code,
+			ClusterID:    criteriaDependency.ClusterID,
+			Dependency:   entity.DependencyKind(*control, criteriaDependency.ClusterID),
+			Weight:       criteriaDependency.Weight,
+		})
+	}
+
+	result = &dto.CollectionDependenciesResponse{
+		CollectionID:  payload.ID,
+		DecisionModel: collection.DecisionModel,
+		Dependencies:  dependencies,
+	}
+
+	return result, nil
+}
+
+func (s *service) UpdateDependency(ctx context.Context, payload *dto.CollectionDependencyUpdateRequest) (*dto.CollectionDependencyResponse, error) {
+	var result *dto.CollectionDependencyResponse
+
+	_, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	control, err := findLeaf(criterias, payload.CriteriaID)
+	if err != nil {
+		return result, err
+	}
+
+	elements, err := entity.ClusterElements(criterias, payload.ClusterID)
+	if err != nil {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	pairwise, estimated, err := buildPairwise(payload.PairwiseInput, payload.Completion, len(elements))
+	if err != nil {
+		return result, err
+	}
+
+	criteriaData, err := dependencyCriteriaData(criterias, payload.ClusterID, pairwise, payload.Method)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+	setEstimated(criteriaData, payload.Completion, estimated)
+
+	if !criteriaData.IsConsistent && !payload.Force {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Pairwise matrix is", criteriaData.ConsistencyRatio))
+	}
+
+	weight := payload.Weight
+	if weight == 0 {
+		weight = 1
+	}
+
+	_, err = s.CriteriaDependencyRepository.Upsert(ctx, &entity.CriteriaDependencyEntityModel{
+		Entity:                   abstraction.Entity{ID: uuid.NewString()},
+		CriteriaDependencyEntity: entity.CriteriaDependencyEntity{Pairwise: pairwise, Weight: weight},
+		CollectionID:             payload.ID,
+		CriteriaID:               payload.CriteriaID,
+		ClusterID:                payload.ClusterID,
+	})
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+	}
+
+	result = &dto.CollectionDependencyResponse{
+		CriteriaData: *criteriaData,
+		CollectionID: payload.ID,
+		CriteriaID:   control.ID,
+		CriteriaCode: control.Code,
+		ClusterID:    payload.ClusterID,
+		Dependency:   entity.DependencyKind(*control, payload.ClusterID),
+		Weight:       weight,
+	}
+
+	return result, nil
+}
+
+func (s *service) DeleteDependency(ctx context.Context, payload *dto.CollectionDependencyDeleteRequest) (*dto.CollectionDependencyDeleteResponse, error) {
+	var result *dto.CollectionDependencyDeleteResponse
+
+	err := s.CriteriaDependencyRepository.Delete(ctx, &payload.ID, &payload.CriteriaID, &payload.ClusterID)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	result = &dto.CollectionDependencyDeleteResponse{
+		CollectionID: payload.ID,
+		CriteriaID:   payload.CriteriaID,
+		ClusterID:    payload.ClusterID,
+	}
+
+	return result, nil
+}
+
+func (s *service) FindValueFunctions(ctx context.Context, payload *dto.CollectionValueFunctionsGetRequest) (*dto.CollectionValueFunctionsResponse, error) {
+	var result *dto.CollectionValueFunctionsResponse
+
+	_, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criteriaCodes := make(map[string]string)
+	for _, criteria := range criterias {
+		criteriaCodes[criteria.ID] = criteria.Code
+	}
+
+	valueFunctions, err := s.ValueFunctionRepository.FindByCollectionID(ctx, &payload.ID)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	datas := make([]dto.CollectionValueFunctionResponse, 0)
+	for _, valueFunction := range valueFunctions {
+		datas = append(datas, dto.CollectionValueFunctionResponse{
+			ValueFunctionEntity: valueFunction.ValueFunctionEntity,
+			CollectionID:        payload.ID,
+			CriteriaID:          valueFunction.CriteriaID,
+			CriteriaCode:        criteriaCodes[valueFunction.CriteriaID],
+			Preview:             valueFunctionPreview(valueFunction.Function()),
+		})
+	}
+
+	result = &dto.CollectionValueFunctionsResponse{
+		CollectionID:   payload.ID,
+		ValueFunctions: datas,
+	}
+
+	return result, nil
+}
+
+func (s *service) UpdateValueFunction(ctx context.Context, payload *dto.CollectionValueFunctionUpdateRequest) (*dto.CollectionValueFunctionResponse, error) {
+	var result *dto.CollectionValueFunctionResponse
+
+	_, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	criteria, err := findLeaf(criterias, payload.CriteriaID)
+	if err != nil {
+		return result, err
+	}
+
+	function := payload.ValueFunctionEntity.Function()
+	if err = function.Validate(); err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	_, err = s.ValueFunctionRepository.Upsert(ctx, &entity.ValueFunctionEntityModel{
+		Entity:              abstraction.Entity{ID: uuid.NewString()},
+		ValueFunctionEntity: payload.ValueFunctionEntity,
+		CollectionID:        payload.ID,
+		CriteriaID:          payload.CriteriaID,
+	})
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+	}
+
+	result = &dto.CollectionValueFunctionResponse{
+		ValueFunctionEntity: payload.ValueFunctionEntity,
+		CollectionID:        payload.ID,
+		CriteriaID:          criteria.ID,
+		CriteriaCode:        criteria.Code,
+		Preview:             valueFunctionPreview(function),
+	}
+
+	return result, nil
+}
+
+func (s *service) DeleteValueFunction(ctx context.Context, payload *dto.CollectionValueFunctionDeleteRequest) (*dto.CollectionValueFunctionDeleteResponse, error) {
+	var result *dto.CollectionValueFunctionDeleteResponse
+
+	err := s.ValueFunctionRepository.Delete(ctx, &payload.ID, &payload.CriteriaID)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	result = &dto.CollectionValueFunctionDeleteResponse{
+		CollectionID: payload.ID,
+		CriteriaID:   payload.CriteriaID,
+	}
+
+	return result, nil
+}
+
+// valueFunctionPreview samples the value function at 11 points over its domain.
+func valueFunctionPreview(function ahp.ValueFunction) []ahp.ValuePoint {
+	lo, hi := function.Min, function.Max
+	if len(function.Points) > 0 && (function.Type == ahp.ValuePiecewiseLinear || function.Type == ahp.ValueStep) {
+		lo, hi = function.Points[0].X, function.Points[len(function.Points)-1].X
+	}
+
+	preview := make([]ahp.ValuePoint, 0)
+	for k := 0; k <= 10; k++ {
+		x := lo + (hi-lo)*float64(k)/10
+		preview = append(preview, ahp.ValuePoint{X: x, Y: constant.RoundFloat(function.Value(x), 4)})
+	}
+	return preview
+}
+
+// findLeaf returns the leaf criteria with the given id, only leaf criteria can control a dependency.
+func findLeaf(criterias []entity.CriteriaEntityModel, criteriaID string) (*entity.CriteriaEntityModel, error) {
+	leaves := entity.LeafCriterias(criterias)
+	for i := range leaves {
+		if leaves[i].ID == criteriaID {
+			return &leaves[i], nil
+		}
+	}
+	return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+		fmt.Sprintf("Criteria %s is not a leaf criteria", criteriaID))
+}
+
+func dependencyCriteriaData(criterias []entity.CriteriaEntityModel, clusterID string, pairwise entity.Matrix, method string) (*entity.CriteriaData, error) {
+	elements, err := entity.ClusterElements(criterias, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteriaData, err := entity.NewCriteriaData(pairwise, method)
+	if err != nil {
+		return nil, err
+	}
+	if err = criteriaData.SetCriteria(elements); err != nil {
+		return nil, err
+	}
+
+	return criteriaData, nil
+}
+
+func (s *service) findAlternativeIDs(ctx context.Context, collectionID string) ([]string, error) {
+	alternatives, err := s.AlternativeRepository.FindByCollectionID(ctx, &collectionID)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if len(alternatives) < 2 {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			"Pairwise comparison of alternatives needs at least 2 alternatives")
+	}
+
+	return entity.SortedAlternativeIDs(alternatives), nil
+}
+
+func sameAlternatives(order []string, alternativeIDs []string) bool {
+	if len(order) != len(alternativeIDs) {
+		return false
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range order {
+		seen[id] = true
+	}
+	for _, id := range alternativeIDs {
+		if !seen[id] {
+			return false
+		}
+	}
+	return len(seen) == len(alternativeIDs)
+}
+
+func (s *service) findNodeChildren(ctx context.Context, nodeID string) ([]entity.CriteriaEntityModel, error) {
+	criterias, err := s.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	if nodeID != entity.GoalNodeID {
+		_, err = s.CriteriaRepository.FindByID(ctx, &nodeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+			}
+			return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+	}
+
+	children := entity.ChildCriterias(criterias, nodeID)
+	if len(children) == 0 {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			fmt.Sprintf("Criteria %s has no child criteria to compare", nodeID))
+	}
+
+	return children, nil
+}
+
+func buildPairwise(input entity.PairwiseInput, completion string, n int) (entity.Matrix, []ahp.MatrixCell, error) {
+	incomplete, errs := input.Build(n)
+	if len(errs) > 0 {
+		return nil, nil, response.InputErrorBuilder(errs)
+	}
+
+	//MELENGKAPI PERBANDINGAN YANG KOSONG (NULL)
+	pairwise, estimated, err := ahp.Complete(incomplete, completion)
+	if err != nil {
+		return nil, nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	return pairwise, estimated, nil
+}
+
+func setEstimated(criteriaData *entity.CriteriaData, completion string, estimated []ahp.MatrixCell) {
+	if len(estimated) == 0 {
+		return
+	}
+	criteriaData.Completion = completion
+	if criteriaData.Completion == "" {
+		criteriaData.Completion = ahp.CompletionHarker
+	}
+	criteriaData.EstimatedCells = estimated
+}
+
+func (s *service) newCriteriaData(criteriaMatrix entity.CriteriaMatrixEntity, method string, children []entity.CriteriaEntityModel) (*entity.CriteriaData, error) {
+	criteriaData, err := criteriaMatrix.CriteriaData(method)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	if err = criteriaData.SetCriteria(children); err != nil {
+		return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	return criteriaData, nil
+}
@@ -0,0 +1,230 @@
+package criteria
+
+import (
+	"github.com/labstack/echo/v4"
+	dto "ta13-svc/internal/dto/criteria"
+	"ta13-svc/internal/factory"
+	"ta13-svc/pkg/response"
+)
+
+type handler struct {
+	service *service
+}
+
+var err error
+
+func NewHandler(f *factory.Factory) *handler {
+	service := NewService(f)
+	return &handler{service}
+}
+
+// Get
+// @Summary Get All Criteria
+// @Description Get All Criteria ordered by sort, the order used by the pairwise matrix rows and columns
+// @Tags criteria
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.CriteriasGetResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /criteria [get]
+func (h *handler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	result, err := h.service.FindAll(ctx)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetByID
+// @Summary Get Criteria By ID
+// @Description Get Criteria By ID
+// @Tags criteria
+// @Accept json
+// @Produce json
+// @Param id path string true "id path"
+// @Success 200 {object} dto.CriteriaGetByIDResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /criteria/{id} [get]
+func (h *handler) GetByID(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CriteriaGetByIDRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindByID(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// Create godoc
+// @Summary Create Criteria
+// @Description Create Criteria with its sub_criterias and sub_criteria_pairwise, or the template of its code when they are omitted (a warning is returned when neither exists). The stored matrices of its parent are resized, it compares equally (1) with its siblings
+// @Tags criteria
+// @Accept  json
+// @Produce  json
+// @Param request body dto.CriteriaCreateRequest true "request body"
+// @Success 200 {object} dto.CriteriaCreateResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /criteria [post]
+func (h *handler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CriteriaCreateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.Create(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// Update godoc
+// @Summary Update Criteria
+// @Description Update Criteria, moving it to another parent or changing its sort remaps the stored matrices and criteria dependencies of the affected parents
+// @Tags criteria
+// @Accept  json
+// @Produce  json
+// @Param request body dto.CriteriaUpdateRequest true "request body"
+// @Success 200 {object} dto.CriteriaUpdateResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /criteria [patch]
+func (h *handler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CriteriaUpdateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.Update(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// Delete godoc
+// @Summary Delete Criteria
+// @Description Delete Criteria together with the alternative values and scores that reference it, the stored matrices of its parent drop its row and column, criteria dependencies of the changed clusters are resized and those whose control criteria is gone are deleted
+// @Tags criteria
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Success 200 {object} dto.CriteriaDeleteResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /criteria/{id} [delete]
+func (h *handler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.CriteriaDeleteRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.Delete(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// GetSubCriteria
+// @Summary Get Sub Criteria By Criteria ID
+// @Description Get Sub Criteria with their pairwise matrix, local priorities and consistency ratio
+// @Tags criteria
+// @Accept json
+// @Produce json
+// @Param id path string true "id path"
+// @Success 200 {object} dto.SubCriteriaResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /criteria/{id}/subcriteria [get]
+func (h *handler) GetSubCriteria(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.SubCriteriaGetRequest)
+	if err = c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err = c.Validate(payload); err != nil {
+		response := response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		return response.Send(c)
+	}
+
+	result, err := h.service.FindSubCriteria(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
+
+// UpdateSubCriteria godoc
+// @Summary Update Sub Criteria By Criteria ID
+// @Description Replace Sub Criteria (a missing code is kept from the stored sub criteria with the same id or label, otherwise derived from the label; removing a code that alternatives still rate directly is rejected), their optional [min, max) range bins and their pairwise matrix, rebin the stored raw values of alternatives, then recalculate the scores of up to 20 collections that were already calculated, the rest are reset and listed as stale_collections
+// @Tags criteria
+// @Accept  json
+// @Produce  json
+// @Param id path string true "id path"
+// @Param request body dto.SubCriteriaUpdateRequest true "request body"
+// @Success 200 {object} dto.SubCriteriaResponseDoc
+// @Failure 400 {object} response.errorResponse
+// @Failure 404 {object} response.errorResponse
+// @Failure 500 {object} response.errorResponse
+// @Router /criteria/{id}/subcriteria [put]
+func (h *handler) UpdateSubCriteria(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	payload := new(dto.SubCriteriaUpdateRequest)
+	if err := c.Bind(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.BadRequest, err).Send(c)
+	}
+	if err := c.Validate(payload); err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.Validation, err).Send(c)
+	}
+
+	result, err := h.service.UpdateSubCriteria(ctx, payload)
+	if err != nil {
+		return response.ErrorResponse(err).Send(c)
+	}
+
+	return response.SuccessResponse(result).Send(c)
+}
@@ -0,0 +1,13 @@
+package criteria
+
+import "github.com/labstack/echo/v4"
+
+func (h *handler) Route(g *echo.Group) {
+	g.GET("", h.Get)
+	g.GET("/:id", h.GetByID)
+	g.POST("", h.Create)
+	g.PATCH("", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.GET("/:id/subcriteria", h.GetSubCriteria)
+	g.PUT("/:id/subcriteria", h.UpdateSubCriteria)
+}
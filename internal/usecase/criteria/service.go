@@ -0,0 +1,665 @@
+package criteria
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+	"ta13-svc/internal/abstraction"
+	dto "ta13-svc/internal/dto/criteria"
+	"ta13-svc/internal/entity"
+	"ta13-svc/internal/factory"
+	"ta13-svc/internal/repository"
+	ahpUsecase "ta13-svc/internal/usecase/ahp"
+	"ta13-svc/pkg/response"
+	"ta13-svc/pkg/utils/ahp"
+	"ta13-svc/pkg/utils/trxmanager"
+	"time"
+)
+
+const (
+	maxRecalculatedCollections = 20
+	recalculateTimeout         = 30 * time.Second
+)
+
+type Service interface {
+	FindAll(ctx context.Context) ([]entity.CriteriaEntityModel, error)
+	FindByID(ctx context.Context, payload *dto.CriteriaGetByIDRequest) (*dto.CriteriaGetByIDResponse, error)
+	Create(ctx context.Context, payload *dto.CriteriaCreateRequest) (*dto.CriteriaCreateResponse, error)
+	Update(ctx context.Context, payload *dto.CriteriaUpdateRequest) (*dto.CriteriaUpdateResponse, error)
+	Delete(ctx context.Context, payload *dto.CriteriaDeleteRequest) (*dto.CriteriaDeleteResponse, error)
+	FindSubCriteria(ctx context.Context, payload *dto.SubCriteriaGetRequest) (*dto.SubCriteriaResponse, error)
+	UpdateSubCriteria(ctx context.Context, payload *dto.SubCriteriaUpdateRequest) (*dto.SubCriteriaResponse, error)
+}
+
+type service struct {
+	Repository            repository.CriteriaRepository
+	SubCriteriaRepository repository.SubCriteriaRepository
+	CollectionRepository  repository.CollectionRepository
+	AHPService            ahpUsecase.Service
+	Db                    *gorm.DB
+}
+
+func NewService(f *factory.Factory) *service {
+	repository := f.CriteriaRepository
+	subCriteriaRepository := f.SubCriteriaRepository
+	collectionRepository := f.CollectionRepository
+	ahpService := ahpUsecase.NewService(f)
+	db := f.Db
+	return &service{repository, subCriteriaRepository, collectionRepository, ahpService, db}
+}
+
+func (s *service) FindAll(ctx context.Context) ([]entity.CriteriaEntityModel, error) {
+	datas := make([]entity.CriteriaEntityModel, 0)
+
+	datas, err = s.Repository.FindAll(ctx)
+
+	if err != nil {
+		return datas, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	return datas, nil
+}
+
+func (s *service) FindByID(ctx context.Context, payload *dto.CriteriaGetByIDRequest) (*dto.CriteriaGetByIDResponse, error) {
+	var result *dto.CriteriaGetByIDResponse
+
+	data, err := s.Repository.FindByID(ctx, &payload.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	result = &dto.CriteriaGetByIDResponse{
+		CriteriaEntityModel: *data,
+	}
+
+	return result, nil
+}
+
+func (s *service) Create(ctx context.Context, payload *dto.CriteriaCreateRequest) (*dto.CriteriaCreateResponse, error) {
+	var result *dto.CriteriaCreateResponse
+	var data *entity.CriteriaEntityModel
+	warnings := make([]string, 0)
+
+	//SUB KRITERIA DARI PAYLOAD, JIKA KOSONG DARI TEMPLATE KODE KRITERIA
+	template, hasTemplate := ahp.DefaultSubCriteria()[payload.Code]
+	subCriteriaEntities, subCriteriaPairwise := entity.SubCriteriaFromTemplate(template), entity.Matrix(template.Pairwise)
+	if len(payload.SubCriterias) > 0 {
+		hasTemplate = true
+		subCriteriaEntities, subCriteriaPairwise = payload.SubCriterias, payload.SubCriteriaPairwise
+		if subCriteriaPairwise == nil {
+			subCriteriaPairwise = entity.NewEqualMatrix(len(subCriteriaEntities))
+		}
+		var errs []ahp.InputError
+		if subCriteriaPairwise, errs = subCriteriaPairwise.Validate("sub_criteria_pairwise"); len(errs) > 0 {
+			return result, response.InputErrorBuilder(errs)
+		}
+
+		if err = entity.NormalizeCodes(subCriteriaEntities); err != nil {
+			return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		}
+		if err = entity.ValidateBins(subCriteriaEntities); err != nil {
+			return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+		}
+	}
+	if !hasTemplate {
+		warnings = append(warnings, fmt.Sprintf("Criteria %s has no sub criteria template, set its sub criteria with PUT /criteria/{id}/subcriteria before scoring alternatives on it", payload.Code))
+	}
+
+	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
+		criteriaRepository := f.CriteriaRepository
+
+		before, err := criteriaRepository.FindAll(ctx)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+
+		if payload.Type == "" {
+			payload.Type = entity.CriteriaTypeBenefit
+		}
+
+		data = &entity.CriteriaEntityModel{
+			Entity:         abstraction.Entity{ID: uuid.NewString()},
+			CriteriaEntity: payload.CriteriaEntity,
+		}
+
+		parentID, err := validateParent(ctx, f, data.ID, payload.ParentID)
+		if err != nil {
+			return err
+		}
+		data.ParentID = parentID
+
+		_, err = criteriaRepository.Create(ctx, data)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		if err = resizeMatrices(ctx, f, before); err != nil {
+			return err
+		}
+
+		if !hasTemplate {
+			return nil
+		}
+
+		subCriterias, _, err := entity.NewSubCriterias(data.ID, subCriteriaEntities, subCriteriaPairwise, "")
+		if err != nil {
+			return response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+		}
+
+		data.SubCriterias, err = f.SubCriteriaRepository.ReplaceByCriteriaID(ctx, &data.ID, subCriterias)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		data.SubCriteriaPairwise = subCriteriaPairwise
+		_, err = criteriaRepository.Update(ctx, &data.ID, &entity.CriteriaEntityModel{SubCriteriaPairwise: data.SubCriteriaPairwise})
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	result = &dto.CriteriaCreateResponse{
+		CriteriaEntityModel: *data,
+		Warnings:            warnings,
+	}
+
+	return result, nil
+}
+
+func (s *service) Update(ctx context.Context, payload *dto.CriteriaUpdateRequest) (*dto.CriteriaUpdateResponse, error) {
+	var result *dto.CriteriaUpdateResponse
+	var data *entity.CriteriaEntityModel
+
+	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
+		criteriaRepository := f.CriteriaRepository
+
+		data = &entity.CriteriaEntityModel{
+			CriteriaEntity: payload.CriteriaEntity,
+			Entity:         abstraction.Entity{ID: payload.ID},
+		}
+		_, err := criteriaRepository.FindByID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+
+		before, err := criteriaRepository.FindAll(ctx)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+
+		//PARENT_ID KOSONG MEMINDAHKAN KRITERIA KE BAWAH GOAL
+		if payload.ParentID != nil {
+			parentID, err := validateParent(ctx, f, payload.ID, payload.ParentID)
+			if err != nil {
+				return err
+			}
+
+			err = criteriaRepository.UpdateParent(ctx, &payload.ID, parentID)
+			if err != nil {
+				return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+			}
+			data.ParentID = nil
+		}
+
+		_, err = criteriaRepository.Update(ctx, &payload.ID, data)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		//PINDAH INDUK ATAU URUTAN MENGUBAH ANAK DARI NODE
+		return resizeMatrices(ctx, f, before)
+	}); err != nil {
+		return result, err
+	}
+
+	result = &dto.CriteriaUpdateResponse{
+		CriteriaEntityModel: *data,
+	}
+
+	return result, nil
+}
+
+func (s *service) Delete(ctx context.Context, payload *dto.CriteriaDeleteRequest) (*dto.CriteriaDeleteResponse, error) {
+	var result *dto.CriteriaDeleteResponse
+
+	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
+		criteriaRepository := f.CriteriaRepository
+
+		data, err := criteriaRepository.FindByID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+
+		before, err := criteriaRepository.FindAll(ctx)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+
+		//ANAK KRITERIA DIPINDAHKAN KE INDUK DARI KRITERIA YANG DIHAPUS
+		err = criteriaRepository.ReplaceParent(ctx, &payload.ID, data.ParentID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		err = f.CriteriaMatrixRepository.DeleteByNodeID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		err = f.AlternativeMatrixRepository.DeleteByCriteriaID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		err = f.CriteriaDependencyRepository.DeleteByCriteriaID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		err = f.ValueFunctionRepository.DeleteByCriteriaID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		_, err = criteriaRepository.Delete(ctx, &payload.ID, data)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		return resizeMatrices(ctx, f, before)
+	}); err != nil {
+		return result, err
+	}
+
+	result = &dto.CriteriaDeleteResponse{
+		ID: &payload.ID,
+	}
+
+	return result, nil
+}
+
+// resizeMatrices remaps the stored matrices of every node whose children changed since before, in every collection
+// and in the default template. A new child compares equally with its siblings, a node without children loses its
+// matrices. The criteria dependencies follow, see resizeDependencies.
+func resizeMatrices(ctx context.Context, f *factory.Factory, before []entity.CriteriaEntityModel) error {
+	after, err := f.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	nodeIDs := []string{entity.GoalNodeID}
+	for _, criteria := range before {
+		nodeIDs = append(nodeIDs, criteria.ID)
+	}
+
+	for _, nodeID := range nodeIDs {
+		oldIDs := criteriaIDs(entity.ChildCriterias(before, nodeID))
+		newIDs := criteriaIDs(entity.ChildCriterias(after, nodeID))
+		if strings.Join(oldIDs, ",") == strings.Join(newIDs, ",") {
+			continue
+		}
+
+		nodeID := nodeID
+		if len(newIDs) == 0 {
+			if err = f.CriteriaMatrixRepository.DeleteByNodeID(ctx, &nodeID); err != nil {
+				return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+			}
+			continue
+		}
+
+		criteriaMatrices, err := f.CriteriaMatrixRepository.FindAllByNodeID(ctx, &nodeID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+		for i := range criteriaMatrices {
+			criteriaMatrix := &criteriaMatrices[i]
+			criteriaMatrix.CriteriaMatrixEntity = criteriaMatrix.Remap(oldIDs, newIDs)
+			if _, err = f.CriteriaMatrixRepository.Update(ctx, &criteriaMatrix.ID, criteriaMatrix); err != nil {
+				return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+			}
+		}
+	}
+
+	return resizeDependencies(ctx, f, before, after)
+}
+
+// resizeDependencies remaps the dependencies comparing a cluster whose children changed, like the criteria
+// matrices. A dependency whose control criteria is no longer a leaf or whose cluster can no longer be compared
+// (less than 2 criteria or a criteria that is not a leaf) is deleted, the network would reject it.
+func resizeDependencies(ctx context.Context, f *factory.Factory, before []entity.CriteriaEntityModel, after []entity.CriteriaEntityModel) error {
+	dependencies, err := f.CriteriaDependencyRepository.FindAll(ctx)
+	if err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	leaves := make(map[string]bool)
+	for _, leaf := range entity.LeafCriterias(after) {
+		leaves[leaf.ID] = true
+	}
+
+	for i := range dependencies {
+		dependency := &dependencies[i]
+
+		_, err = entity.ClusterElements(after, dependency.ClusterID)
+		if !leaves[dependency.CriteriaID] || err != nil {
+			if err = f.CriteriaDependencyRepository.DeleteByID(ctx, &dependency.ID); err != nil {
+				return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+			}
+			continue
+		}
+
+		oldIDs := criteriaIDs(entity.ChildCriterias(before, dependency.ClusterID))
+		newIDs := criteriaIDs(entity.ChildCriterias(after, dependency.ClusterID))
+		if strings.Join(oldIDs, ",") == strings.Join(newIDs, ",") {
+			continue
+		}
+
+		dependency.Pairwise = entity.CriteriaMatrixEntity{Pairwise: dependency.Pairwise}.Remap(oldIDs, newIDs).Pairwise
+		if _, err = f.CriteriaDependencyRepository.Update(ctx, &dependency.ID, dependency); err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func criteriaIDs(criterias []entity.CriteriaEntityModel) []string {
+	ids := make([]string, 0)
+	for _, criteria := range criterias {
+		ids = append(ids, criteria.ID)
+	}
+	return ids
+}
+
+func validateParent(ctx context.Context, f *factory.Factory, id string, parentID *string) (*string, error) {
+	if parentID == nil || *parentID == "" {
+		return nil, nil
+	}
+
+	criterias, err := f.CriteriaRepository.FindAll(ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	parents := make(map[string]*string)
+	for _, criteria := range criterias {
+		parents[criteria.ID] = criteria.ParentID
+	}
+
+	if _, ok := parents[*parentID]; !ok {
+		return nil, response.CustomErrorBuilder(http.StatusBadRequest, response.E_BAD_REQUEST,
+			fmt.Sprintf("Unknown parent criteria %s", *parentID))
+	}
+
+	//MENCEGAH SIKLUS PADA HIERARKI
+	for current, depth := parentID, 0; current != nil && depth <= len(criterias); current, depth = parents[*current], depth+1 {
+		if *current == id {
+			return nil, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+				"Criteria cannot be moved under itself or one of its descendants")
+		}
+	}
+
+	return parentID, nil
+}
+
+func (s *service) FindSubCriteria(ctx context.Context, payload *dto.SubCriteriaGetRequest) (*dto.SubCriteriaResponse, error) {
+	var result *dto.SubCriteriaResponse
+
+	data, err := s.Repository.FindByID(ctx, &payload.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	subCriterias, err := s.SubCriteriaRepository.FindByCriteriaID(ctx, &payload.ID)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	result = &dto.SubCriteriaResponse{
+		CriteriaID:   data.ID,
+		SubCriterias: subCriterias,
+		Pairwise:     data.SubCriteriaPairwise,
+	}
+
+	if data.SubCriteriaPairwise.IsSquare() {
+		criteriaData, err := entity.NewCriteriaData(data.SubCriteriaPairwise, "")
+		if err != nil {
+			return nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+		}
+		result.Method = criteriaData.Method
+		result.LambdaMax = criteriaData.LambdaMax
+		result.ConsistencyIndex = criteriaData.ConsistencyIndex
+		result.ConsistencyRatio = criteriaData.ConsistencyRatio
+		result.IsConsistent = criteriaData.IsConsistent
+	}
+
+	return result, nil
+}
+
+func (s *service) UpdateSubCriteria(ctx context.Context, payload *dto.SubCriteriaUpdateRequest) (*dto.SubCriteriaResponse, error) {
+	var result *dto.SubCriteriaResponse
+
+	pairwise, errs := payload.Pairwise.Validate("pairwise")
+	if len(errs) > 0 {
+		return result, response.InputErrorBuilder(errs)
+	}
+	payload.Pairwise = pairwise
+
+	subCriteriaEntities := make([]entity.SubCriteriaEntity, 0)
+	ids := make([]string, 0)
+	for _, subCriteria := range payload.SubCriterias {
+		subCriteriaEntities = append(subCriteriaEntities, subCriteria.SubCriteriaEntity)
+		ids = append(ids, subCriteria.ID)
+	}
+
+	stored, err := s.SubCriteriaRepository.FindByCriteriaID(ctx, &payload.ID)
+	if err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	//KODE LAMA DIPERTAHANKAN LEWAT ID ATAU LABEL YANG SAMA
+	if err = entity.KeepCodes(subCriteriaEntities, ids, stored); err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	if err = entity.NormalizeCodes(subCriteriaEntities); err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	if err = entity.ValidateBins(subCriteriaEntities); err != nil {
+		return result, response.ErrorBuilder(&response.ErrorConstant.Validation, err)
+	}
+
+	subCriterias, criteriaData, err := entity.NewSubCriterias(payload.ID, subCriteriaEntities, payload.Pairwise, payload.Method)
+	if err != nil {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	if !criteriaData.IsConsistent && !payload.Force {
+		return result, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+			ahp.InconsistentMessage("Pairwise matrix is", criteriaData.ConsistencyRatio))
+	}
+
+	var rebinned int
+	if err = trxmanager.New(s.Db).WithTrxV2(ctx, func(ctx context.Context, f *factory.Factory) error {
+		criteriaRepository := f.CriteriaRepository
+
+		_, err := criteriaRepository.FindByID(ctx, &payload.ID)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.NotFound, err)
+		}
+
+		if err = checkRemovedCodes(ctx, f, payload.ID, stored, subCriteriaEntities); err != nil {
+			return err
+		}
+
+		subCriterias, err = f.SubCriteriaRepository.ReplaceByCriteriaID(ctx, &payload.ID, subCriterias)
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		_, err = criteriaRepository.Update(ctx, &payload.ID, &entity.CriteriaEntityModel{SubCriteriaPairwise: payload.Pairwise})
+		if err != nil {
+			return response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+
+		rebinned, err = rebinValues(ctx, f, payload.ID, subCriterias)
+		return err
+	}); err != nil {
+		return result, err
+	}
+
+	result = &dto.SubCriteriaResponse{
+		CriteriaID:       payload.ID,
+		SubCriterias:     subCriterias,
+		Pairwise:         payload.Pairwise,
+		Method:           criteriaData.Method,
+		LambdaMax:        criteriaData.LambdaMax,
+		ConsistencyIndex: criteriaData.ConsistencyIndex,
+		ConsistencyRatio: criteriaData.ConsistencyRatio,
+		IsConsistent:     criteriaData.IsConsistent,
+		RebinnedValues:   rebinned,
+	}
+
+	result.RecalculatedCollections, result.FailedCollections, result.StaleCollections, err = s.recalculateCollections(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// recalculateCollections recalculates the scored collections inside the request, at most
+// maxRecalculatedCollections of them and within recalculateTimeout. The scores of the collections left over are
+// reset so they are calculated again on their next request.
+func (s *service) recalculateCollections(ctx context.Context) ([]string, []string, []string, error) {
+	collections, err := s.CollectionRepository.FindAll(ctx)
+	if err != nil {
+		return nil, nil, nil, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, recalculateTimeout)
+	defer cancel()
+
+	//HITUNG ULANG SKOR KOLEKSI YANG SUDAH PERNAH DIHITUNG
+	recalculated := make([]string, 0)
+	failed := make([]string, 0)
+	stale := make([]string, 0)
+	for i := range collections {
+		collection := &collections[i]
+		if !collection.FinalScoreIsCalculated && !collection.ScoreIsCalculated {
+			continue
+		}
+
+		//KOLEKSI DI LUAR BATAS DITANDAI BELUM DIHITUNG
+		if len(recalculated)+len(failed) >= maxRecalculatedCollections || ctx.Err() != nil {
+			if err = s.CollectionRepository.ResetCalculated(context.Background(), &collection.ID); err != nil {
+				return recalculated, failed, stale, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+			}
+			stale = append(stale, collection.ID)
+			continue
+		}
+
+		if collection.FinalScoreIsCalculated {
+			_, err = s.AHPService.CalculateFinalScoreByCollectionID(ctx, &collection.ID, collection.WeightMethod, collection.Synthesis, collection.TiePolicy)
+		} else {
+			_, err = s.AHPService.CalculateScoreAlternativeByCollectionID(ctx, &collection.ID, collection.WeightMethod, collection.Synthesis)
+		}
+
+		if err != nil {
+			logrus.Error(fmt.Sprintf("Failed to recalculate collection %s, %s", collection.ID, err.Error()))
+			failed = append(failed, collection.ID)
+			continue
+		}
+		recalculated = append(recalculated, collection.ID)
+	}
+
+	return recalculated, failed, stale, nil
+}
+
+// checkRemovedCodes rejects sub criteria that drop a code still rated directly by an alternative, its value
+// would fall outside the catalog.
+func checkRemovedCodes(ctx context.Context, f *factory.Factory, criteriaID string, stored []entity.SubCriteriaEntityModel, subCriterias []entity.SubCriteriaEntity) error {
+	kept := make(map[string]bool)
+	for _, subCriteria := range subCriterias {
+		kept[subCriteria.Code] = true
+	}
+
+	removed := make([]string, 0)
+	for _, subCriteria := range stored {
+		if !kept[subCriteria.Code] {
+			removed = append(removed, subCriteria.Code)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	values, err := f.AlternativeRepository.FindRatedValuesByCriteriaID(ctx, &criteriaID, removed)
+	if err != nil {
+		return response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	used := make([]string, 0)
+	counts := make(map[string]int)
+	for _, value := range values {
+		if counts[value.Value] == 0 {
+			used = append(used, value.Value)
+		}
+		counts[value.Value]++
+	}
+	messages := make([]string, 0)
+	for _, code := range used {
+		messages = append(messages, fmt.Sprintf("%s (%d alternatives)", code, counts[code]))
+	}
+
+	return response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+		fmt.Sprintf("Sub criteria codes still used by alternatives cannot be removed: %s, keep their id or label or update those alternatives first",
+			strings.Join(messages, ", ")))
+}
+
+// rebinValues maps the stored raw values of the criteria again onto the new sub criteria bins.
+func rebinValues(ctx context.Context, f *factory.Factory, criteriaID string, subCriterias []entity.SubCriteriaEntityModel) (int, error) {
+	values, err := f.AlternativeRepository.FindRawValuesByCriteriaID(ctx, &criteriaID)
+	if err != nil {
+		return 0, response.ErrorBuilder(&response.ErrorConstant.InternalServerError, err)
+	}
+
+	rebinned := 0
+	for _, value := range values {
+		code, err := entity.ResolveBin(subCriterias, *value.Raw)
+		if err != nil {
+			return 0, response.CustomErrorBuilder(http.StatusUnprocessableEntity, response.E_UNPROCESSABLE_ENTITY,
+				fmt.Sprintf("Alternative %s: %s, adjust the bins so every raw value is covered", value.AlternativeID, err.Error()))
+		}
+		if code == value.Value {
+			continue
+		}
+
+		if err = f.AlternativeRepository.UpdateValue(ctx, &value.ID, code); err != nil {
+			return 0, response.ErrorBuilder(&response.ErrorConstant.UnprocessableEntity, err)
+		}
+		rebinned++
+	}
+
+	return rebinned, nil
+}
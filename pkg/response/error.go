@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"ta13-svc/pkg/log"
+	"ta13-svc/pkg/utils/ahp"
 	"ta13-svc/pkg/utils/date"
 
 	"github.com/labstack/echo/v4"
@@ -17,8 +18,14 @@ import (
 )
 
 type errorResponse struct {
-	Meta  Meta   `json:"meta"`
-	Error string `json:"error"`
+	Meta   Meta         `json:"meta"`
+	Error  string       `json:"error"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 type Error struct {
@@ -148,6 +155,29 @@ func CustomErrorBuilder(code int, err string, message string) *Error {
 	}
 }
 
+func FieldErrorBuilder(errors []FieldError) *Error {
+	return &Error{
+		Response: errorResponse{
+			Meta: Meta{
+				Success: false,
+				Message: "Invalid parameters or payload",
+			},
+			Error:  E_BAD_REQUEST,
+			Errors: errors,
+		},
+		Code: http.StatusBadRequest,
+	}
+}
+
+// InputErrorBuilder reports the errors of a pairwise matrix input as field errors.
+func InputErrorBuilder(errs []ahp.InputError) *Error {
+	fieldErrors := make([]FieldError, 0)
+	for _, e := range errs {
+		fieldErrors = append(fieldErrors, FieldError(e))
+	}
+	return FieldErrorBuilder(fieldErrors)
+}
+
 func ErrorResponse(err error) *Error {
 	re, ok := err.(*Error)
 	if ok {
@@ -1,78 +1,321 @@
 package ahp
 
+import (
+	"fmt"
+	"math"
+)
+
+// SubCriteriaTemplate lists the default sub criteria of a criteria, Codes are the stable machine codes stored in
+// the alternative values and LabelsEN the english display labels, both in the order of Labels.
+type SubCriteriaTemplate struct {
+	Codes    []string
+	Labels   []string
+	LabelsEN []string
+	Pairwise [][]float64
+	Bins     []Bin
+	Domain   Domain
+}
+
+// Bin is the range [Min, Max) of raw measurements of a sub criteria, a nil bound is open.
+type Bin struct {
+	Min *float64
+	Max *float64
+}
+
+// Domain is the physical range [Min, Max] of the raw measurements of a criteria, both bounds are inclusive and a
+// nil bound is open.
+type Domain struct {
+	Min *float64
+	Max *float64
+}
+
+// Check rejects a raw measurement outside the domain.
+func (d Domain) Check(raw float64) error {
+	if math.IsNaN(raw) || math.IsInf(raw, 0) {
+		return fmt.Errorf("raw value %v is not a number", raw)
+	}
+	if d.Min != nil && raw < *d.Min {
+		return fmt.Errorf("raw value %v is below the minimum %v", raw, *d.Min)
+	}
+	if d.Max != nil && raw > *d.Max {
+		return fmt.Errorf("raw value %v is above the maximum %v", raw, *d.Max)
+	}
+	return nil
+}
+
+func domain(min float64, max *float64) Domain {
+	return Domain{Min: &min, Max: max}
+}
+
+// NewDescendingBins splits the raw values at the ascending edges into bins ordered from the highest range down,
+// the order of the template labels. Every bin is [min, max), a value on an edge belongs to the higher range so
+// 100m falls in 101m-200m and 80% in >80%.
+func NewDescendingBins(edges ...float64) []Bin {
+	bins := make([]Bin, 0)
+	for i := len(edges); i >= 0; i-- {
+		var bin Bin
+		if i > 0 {
+			min := edges[i-1]
+			bin.Min = &min
+		}
+		if i < len(edges) {
+			max := edges[i]
+			bin.Max = &max
+		}
+		bins = append(bins, bin)
+	}
+	return bins
+}
+
 func GetRatioIndex() [15]float64 {
 	return [15]float64{0, 0, 0.58, 0.9, 1.12, 1.24, 1.32, 1.41, 1.46, 1.49, 1.51, 1.48, 1.56, 1.57, 1.59}
 }
 
-func TimbulanSampahSubCriteria() map[string]float64 {
-	return map[string]float64{
-		"Jaringan Jalan":      0.086,
-		"Perumahan":           0.439,
-		"Fasilitas Komersial": 0.260,
-		"Fasilitas Umum":      0.139,
-		"Fasilitas Sosial":    0.049,
-		"Ruang Terbuka":       0.026}
-}
-
-func JarakTPASubCriteria() map[string]float64 {
-	return map[string]float64{
-		"Alternatif berada di jangkauan layanan TPA":               0.669,
-		"Alternatif berada di batas terjauh jangkauan layanan TPA": 0.267,
-		"Alternatif tidak berada di jangkauan TPA":                 0.064}
-}
-
-//func KondisiTanahSubCriteria() map[string]float64 {
-//	return map[string]float64{
-//		"Tanah keras tidak memiliki unsur organik dan unsur hara dan kedap air": 0.503,
-//		"Tanah keras tidak memiliki unsur organik dan unsur hara":               0.267,
-//		"Tanah keras tidak memiliki salah satu unsur hara atau unsur organik":   0.260,
-//		"Tanah keras memiliki unsur organik dan unsur hara":                     0.134,
-//		"Bukan tanah keras": 0.068,
-//	}
-//}
-
-func JarakPemukimanSubCriteria() map[string]float64 {
-	return map[string]float64{
-		"0m-100m":   0.035,
-		"101m-200m": 0.068,
-		"201m-300m": 0.134,
-		"301m-400m": 0.260,
-		"401m-500m": 0.503,
+func DefaultSubCriteria() map[string]SubCriteriaTemplate {
+	return map[string]SubCriteriaTemplate{
+		"timbulan_sampah":        TimbulanSampahSubCriteria(),
+		"jarak_tpa":              JarakTPASubCriteria(),
+		"kondisi_tanah":          KondisiTanahSubCriteria(),
+		"jarak_pemukiman":        JarakPemukimanSubCriteria(),
+		"jarak_sungai":           JarakSungaiSubCriteria(),
+		"partisipasi_masyarakat": PartisipasiMasyarakatSubCriteria(),
+		"cakupan_rumah":          CakupanRumahSubCriteria(),
+		"aksesibilitas":          AksesibilitasSubCriteria(),
+	}
+}
+
+// LegacySubCriteriaWeights are the fixed sub criteria weights used before they were derived from pairwise
+// matrices, keyed by criteria code in the order of the template labels. Kondisi tanah had none.
+func LegacySubCriteriaWeights() map[string][]float64 {
+	three := []float64{0.669, 0.267, 0.064}
+	five := []float64{0.503, 0.260, 0.134, 0.068, 0.035}
+	return map[string][]float64{
+		"timbulan_sampah":        {0.439, 0.260, 0.139, 0.086, 0.049, 0.026},
+		"jarak_tpa":              three,
+		"jarak_pemukiman":        five,
+		"jarak_sungai":           three,
+		"partisipasi_masyarakat": five,
+		"cakupan_rumah":          five,
+		"aksesibilitas":          three,
+	}
+}
+
+// legacyPairwise is the consistent matrix w_i/w_j of the legacy weights, every weighting method gives them back
+// (normalized to sum 1).
+func legacyPairwise(code string) [][]float64 {
+	return RatioMatrix(LegacySubCriteriaWeights()[code])
+}
+
+func TimbulanSampahSubCriteria() SubCriteriaTemplate {
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"perumahan",
+			"fasilitas_komersial",
+			"fasilitas_umum",
+			"jaringan_jalan",
+			"fasilitas_sosial",
+			"ruang_terbuka",
+		},
+		Labels: []string{
+			"Perumahan",
+			"Fasilitas Komersial",
+			"Fasilitas Umum",
+			"Jaringan Jalan",
+			"Fasilitas Sosial",
+			"Ruang Terbuka",
+		},
+		LabelsEN: []string{
+			"Housing",
+			"Commercial facilities",
+			"Public facilities",
+			"Road network",
+			"Social facilities",
+			"Open space",
+		},
+		Pairwise: legacyPairwise("timbulan_sampah"),
+	}
+}
+
+func JarakTPASubCriteria() SubCriteriaTemplate {
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"dalam_jangkauan_tpa",
+			"batas_jangkauan_tpa",
+			"luar_jangkauan_tpa",
+		},
+		Labels: []string{
+			"Alternatif berada di jangkauan layanan TPA",
+			"Alternatif berada di batas terjauh jangkauan layanan TPA",
+			"Alternatif tidak berada di jangkauan TPA",
+		},
+		LabelsEN: []string{
+			"Within the service range of the landfill",
+			"At the outer edge of the service range of the landfill",
+			"Outside the service range of the landfill",
+		},
+		Pairwise: legacyPairwise("jarak_tpa"),
+	}
+}
+
+func KondisiTanahSubCriteria() SubCriteriaTemplate {
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"keras_tanpa_organik_hara_kedap_air",
+			"keras_tanpa_organik_hara",
+			"keras_tanpa_organik_atau_hara",
+			"keras_dengan_organik_hara",
+			"bukan_tanah_keras",
+		},
+		Labels: []string{
+			"Tanah keras tidak memiliki unsur organik dan unsur hara dan kedap air",
+			"Tanah keras tidak memiliki unsur organik dan unsur hara",
+			"Tanah keras tidak memiliki salah satu unsur hara atau unsur organik",
+			"Tanah keras memiliki unsur organik dan unsur hara",
+			"Bukan tanah keras",
+		},
+		LabelsEN: []string{
+			"Hard soil without organic matter and nutrients, impermeable to water",
+			"Hard soil without organic matter and nutrients",
+			"Hard soil lacking either nutrients or organic matter",
+			"Hard soil with organic matter and nutrients",
+			"Not hard soil",
+		},
+		Pairwise: [][]float64{
+			{1, 2, 2, 6, 6},
+			{1.0 / 2, 1, 2, 2, 2},
+			{1.0 / 2, 1.0 / 2, 1, 3, 6},
+			{1.0 / 6, 1.0 / 2, 1.0 / 3, 1, 4},
+			{1.0 / 6, 1.0 / 2, 1.0 / 6, 1.0 / 4, 1},
+		},
+	}
+}
+
+func JarakPemukimanSubCriteria() SubCriteriaTemplate {
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"401_500m",
+			"301_400m",
+			"201_300m",
+			"101_200m",
+			"0_100m",
+		},
+		Labels: []string{
+			"401m-500m",
+			"301m-400m",
+			"201m-300m",
+			"101m-200m",
+			"0m-100m",
+		},
+		LabelsEN: []string{
+			"401m-500m",
+			"301m-400m",
+			"201m-300m",
+			"101m-200m",
+			"0m-100m",
+		},
+		Pairwise: legacyPairwise("jarak_pemukiman"),
+		Bins:     NewDescendingBins(100, 200, 300, 400),
+		Domain:   domain(0, nil),
 	}
 }
 
-func JarakSungaiSubCriteria() map[string]float64 {
-	return map[string]float64{
-		"Lokasi memenuhi peli banjir":          0.669,
-		"Lokasi memenuhi sebagian peli banjir": 0.267,
-		"Lokasi tidak memenuhi peli banjir":    0.064,
+func JarakSungaiSubCriteria() SubCriteriaTemplate {
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"memenuhi_peil_banjir",
+			"sebagian_peil_banjir",
+			"tidak_memenuhi_peil_banjir",
+		},
+		Labels: []string{
+			"Lokasi memenuhi peil banjir",
+			"Lokasi memenuhi sebagian peil banjir",
+			"Lokasi tidak memenuhi peil banjir",
+		},
+		LabelsEN: []string{
+			"Location meets the flood level",
+			"Location partly meets the flood level",
+			"Location does not meet the flood level",
+		},
+		Pairwise: legacyPairwise("jarak_sungai"),
 	}
 }
 
-func PartisipasiMasyarakatSubCriteria() map[string]float64 {
-	return map[string]float64{
-		"<20% Masyarakat Setuju":    0.035,
-		"21%-40% Masyarakat Setuju": 0.068,
-		"41%-60% Masyarakat Setuju": 0.134,
-		"61%-81% Masyarakat Setuju": 0.260,
-		">80% Masyarakat Setuju":    0.503,
+func PartisipasiMasyarakatSubCriteria() SubCriteriaTemplate {
+	percent := 100.0
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"setuju_lebih_80",
+			"setuju_61_80",
+			"setuju_41_60",
+			"setuju_21_40",
+			"setuju_kurang_20",
+		},
+		Labels: []string{
+			">80% Masyarakat Setuju",
+			"61%-80% Masyarakat Setuju",
+			"41%-60% Masyarakat Setuju",
+			"21%-40% Masyarakat Setuju",
+			"<20% Masyarakat Setuju",
+		},
+		LabelsEN: []string{
+			">80% of residents agree",
+			"61%-80% of residents agree",
+			"41%-60% of residents agree",
+			"21%-40% of residents agree",
+			"<20% of residents agree",
+		},
+		Pairwise: legacyPairwise("partisipasi_masyarakat"),
+		Bins:     NewDescendingBins(20, 40, 60, 80),
+		Domain:   domain(0, &percent),
 	}
 }
 
-func CakupanRumahSubCriteria() map[string]float64 {
-	return map[string]float64{
-		"<40 Rumah":     0.035,
-		"41-80 Rumah":   0.068,
-		"81-120 Rumah":  0.134,
-		"121-160 Rumah": 0.260,
-		">160 Rumah":    0.503,
+func CakupanRumahSubCriteria() SubCriteriaTemplate {
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"lebih_160_rumah",
+			"121_160_rumah",
+			"81_120_rumah",
+			"41_80_rumah",
+			"kurang_40_rumah",
+		},
+		Labels: []string{
+			">160 Rumah",
+			"121-160 Rumah",
+			"81-120 Rumah",
+			"41-80 Rumah",
+			"<40 Rumah",
+		},
+		LabelsEN: []string{
+			">160 houses",
+			"121-160 houses",
+			"81-120 houses",
+			"41-80 houses",
+			"<40 houses",
+		},
+		Pairwise: legacyPairwise("cakupan_rumah"),
+		Bins:     NewDescendingBins(40, 80, 120, 160),
+		Domain:   domain(0, nil),
 	}
 }
 
-func AksesibilitasSubCriteria() map[string]float64 {
-	return map[string]float64{
-		"Kondisi jalan bagus dan bisa dilewati kendaraan pengangkut sampah":                                                                                    0.669,
-		"Kondisi jalan bagus, tetapi tidak bisa dilewati kendaraan pengangkut sampah atau jalan tidak bagus, tetapi bisa dilewati kendaraan pengangkut sampah": 0.267,
-		"Kondisi jalan tidak bagus dan tidak bisa dilewati kendaraan pengangkut sampah":                                                                        0.064,
+func AksesibilitasSubCriteria() SubCriteriaTemplate {
+	return SubCriteriaTemplate{
+		Codes: []string{
+			"jalan_bagus_dapat_dilewati",
+			"salah_satu_terpenuhi",
+			"jalan_buruk_tidak_dapat_dilewati",
+		},
+		Labels: []string{
+			"Kondisi jalan bagus dan bisa dilewati kendaraan pengangkut sampah",
+			"Kondisi jalan bagus, tetapi tidak bisa dilewati kendaraan pengangkut sampah atau jalan tidak bagus, tetapi bisa dilewati kendaraan pengangkut sampah",
+			"Kondisi jalan tidak bagus dan tidak bisa dilewati kendaraan pengangkut sampah",
+		},
+		LabelsEN: []string{
+			"Good road that garbage trucks can pass",
+			"Good road that garbage trucks cannot pass, or a poor road that garbage trucks can pass",
+			"Poor road that garbage trucks cannot pass",
+		},
+		Pairwise: legacyPairwise("aksesibilitas"),
 	}
 }
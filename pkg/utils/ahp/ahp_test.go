@@ -0,0 +1,46 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDefaultSubCriteriaConsistent(t *testing.T) {
+	for code, template := range DefaultSubCriteria() {
+		n := len(template.Labels)
+		if len(template.Pairwise) != n || len(template.Codes) != n || len(template.LabelsEN) != n {
+			t.Fatalf("%s: template has %d labels but %d rows, %d codes, %d english labels",
+				code, n, len(template.Pairwise), len(template.Codes), len(template.LabelsEN))
+		}
+
+		cr, err := MatrixConsistencyRatio(template.Pairwise, MethodEigenvector)
+		if err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+		if cr >= ConsistencyThreshold {
+			t.Errorf("%s: CR %.4f is not below %.1f", code, cr, ConsistencyThreshold)
+		}
+	}
+}
+
+func TestDefaultSubCriteriaReproduceLegacyWeights(t *testing.T) {
+	templates := DefaultSubCriteria()
+	for code, legacy := range LegacySubCriteriaWeights() {
+		sum := 0.0
+		for _, w := range legacy {
+			sum += w
+		}
+
+		for _, method := range []string{MethodAverage, MethodEigenvector, MethodGeometricMean} {
+			weights, _, err := Weights(templates[code].Pairwise, method)
+			if err != nil {
+				t.Fatalf("%s %s: %v", code, method, err)
+			}
+			for i := range legacy {
+				if math.Abs(weights[i]-legacy[i]/sum) > 1e-9 {
+					t.Errorf("%s %s: weight %d is %.6f, legacy %.6f", code, method, i, weights[i], legacy[i]/sum)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package ahp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	StochasticTolerance = 1e-6
+	LimitMaxIteration   = 10000
+)
+
+type LimitResult struct {
+	Priorities []float64 `json:"priorities"`
+	Iterations int       `json:"iterations"`
+	Cesaro     bool      `json:"cesaro"`
+}
+
+// LimitPriorities raises the column stochastic supermatrix to its limit and returns the limit column of the
+// source element. A cyclic supermatrix does not converge, its Cesàro limit is taken from the lazy matrix
+// (I+W)/2 which has the same limit but no cycles.
+func LimitPriorities(supermatrix [][]float64, source int, tolerance float64, maxIteration int) (*LimitResult, error) {
+	n := len(supermatrix)
+	if n == 0 || source < 0 || source >= n {
+		return nil, errors.New("supermatrix is empty or the source is out of range")
+	}
+	for j := 0; j < n; j++ {
+		if len(supermatrix[j]) != n {
+			return nil, errors.New("supermatrix must be square")
+		}
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			if supermatrix[i][j] < 0 {
+				return nil, fmt.Errorf("supermatrix has a negative entry at %d,%d", i, j)
+			}
+			sum += supermatrix[i][j]
+		}
+		if math.Abs(sum-1) > StochasticTolerance {
+			return nil, fmt.Errorf("column %d of the weighted supermatrix sums to %.6f instead of 1", j, sum)
+		}
+	}
+
+	if priorities, iterations, ok := powerLimit(supermatrix, source, tolerance, maxIteration); ok {
+		return &LimitResult{Priorities: priorities, Iterations: iterations}, nil
+	}
+
+	//SUPERMATRIKS SIKLIK, GUNAKAN MATRIKS (I+W)/2
+	lazy := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		lazy[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			lazy[i][j] = supermatrix[i][j] / 2
+		}
+		lazy[i][i] += 0.5
+	}
+	priorities, iterations, ok := powerLimit(lazy, source, tolerance, maxIteration)
+	if !ok {
+		return nil, fmt.Errorf("supermatrix did not converge after %d iterations", maxIteration)
+	}
+
+	return &LimitResult{Priorities: priorities, Iterations: iterations, Cesaro: true}, nil
+}
+
+func powerLimit(matrix [][]float64, source int, tolerance float64, maxIteration int) ([]float64, int, bool) {
+	n := len(matrix)
+	v := make([]float64, n)
+	v[source] = 1
+
+	for iteration := 1; iteration <= maxIteration; iteration++ {
+		next := make([]float64, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				next[i] += matrix[i][j] * v[j]
+			}
+		}
+
+		diff := 0.0
+		for i := 0; i < n; i++ {
+			diff = math.Max(diff, math.Abs(next[i]-v[i]))
+		}
+		v = next
+		if diff < tolerance {
+			return v, iteration, true
+		}
+	}
+
+	return v, maxIteration, false
+}
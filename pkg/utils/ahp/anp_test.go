@@ -0,0 +1,36 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLimitPriorities(t *testing.T) {
+	//pi = W pi GIVES pi = (2/7, 5/7)
+	limit, err := LimitPriorities([][]float64{
+		{0.5, 0.2},
+		{0.5, 0.8},
+	}, 0, EigenvectorTolerance, LimitMaxIteration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit.Cesaro || math.Abs(limit.Priorities[0]-2.0/7) > 1e-6 || math.Abs(limit.Priorities[1]-5.0/7) > 1e-6 {
+		t.Errorf("expected (2/7, 5/7) without cesaro, got %+v", limit)
+	}
+
+	//SIKLIK, LIMIT CESARO (1/2, 1/2)
+	limit, err = LimitPriorities([][]float64{
+		{0, 1},
+		{1, 0},
+	}, 0, EigenvectorTolerance, LimitMaxIteration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !limit.Cesaro || math.Abs(limit.Priorities[0]-0.5) > 1e-6 || math.Abs(limit.Priorities[1]-0.5) > 1e-6 {
+		t.Errorf("expected the cesaro limit (1/2, 1/2), got %+v", limit)
+	}
+
+	if _, err = LimitPriorities([][]float64{{0.5, 0}, {0.4, 1}}, 0, EigenvectorTolerance, LimitMaxIteration); err == nil {
+		t.Error("expected an error for a column that does not sum to 1")
+	}
+}
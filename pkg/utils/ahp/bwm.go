@@ -0,0 +1,240 @@
+package ahp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const MethodBWM = "bwm"
+
+const simplexEpsilon = 1e-12
+
+type BWMResult struct {
+	Weights          []float64 `json:"weights"`
+	Xi               float64   `json:"xi"`
+	ConsistencyIndex float64   `json:"consistency_index"`
+	ConsistencyRatio float64   `json:"consistency_ratio"`
+}
+
+// ValidateBestWorst checks the best-to-others and others-to-worst vectors of n criteria, both on the 1..9 scale
+// with a_BB = a_WW = 1 and the same a_BW.
+func ValidateBestWorst(field string, n int, best int, worst int, bestToOthers []float64, othersToWorst []float64) []InputError {
+	errs := make([]InputError, 0)
+	if n < 2 {
+		return append(errs, InputError{Field: field, Message: "best worst method needs at least 2 criteria"})
+	}
+	if best < 0 || best >= n {
+		errs = append(errs, InputError{Field: field + ".best", Message: fmt.Sprintf("best %d is out of range 0..%d", best, n-1)})
+	}
+	if worst < 0 || worst >= n {
+		errs = append(errs, InputError{Field: field + ".worst", Message: fmt.Sprintf("worst %d is out of range 0..%d", worst, n-1)})
+	}
+	if best == worst {
+		errs = append(errs, InputError{Field: field + ".worst", Message: "best and worst must be different criteria"})
+	}
+
+	vectors := map[string][]float64{"best_to_others": bestToOthers, "others_to_worst": othersToWorst}
+	for _, name := range []string{"best_to_others", "others_to_worst"} {
+		vector := vectors[name]
+		if len(vector) != n {
+			errs = append(errs, InputError{Field: fmt.Sprintf("%s.%s", field, name), Message: fmt.Sprintf("must have %d values", n)})
+			continue
+		}
+		for j, value := range vector {
+			if value < 1 || value > SaatyMax {
+				errs = append(errs, InputError{Field: fmt.Sprintf("%s.%s[%d]", field, name, j), Message: fmt.Sprintf("value %v must be between 1 and 9", value)})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if bestToOthers[best] != 1 {
+		errs = append(errs, InputError{Field: fmt.Sprintf("%s.best_to_others[%d]", field, best), Message: "best compared to itself must be 1"})
+	}
+	if othersToWorst[worst] != 1 {
+		errs = append(errs, InputError{Field: fmt.Sprintf("%s.others_to_worst[%d]", field, worst), Message: "worst compared to itself must be 1"})
+	}
+	if bestToOthers[worst] != othersToWorst[best] {
+		errs = append(errs, InputError{Field: fmt.Sprintf("%s.others_to_worst[%d]", field, best),
+			Message: fmt.Sprintf("best to worst must equal best_to_others[%d] = %v", worst, bestToOthers[worst])})
+	}
+
+	return errs
+}
+
+// BestWorstWeights solves the linear BWM model min ξ s.t. |w_B - a_Bj w_j| <= ξ, |w_j - a_jW w_W| <= ξ,
+// Σw = 1, w >= 0. Fixing ξ = 1 and maximizing Σw gives the same weights after normalization with ξ = 1/Σw.
+// The consistency ratio is ξ over the consistency index of a_BW.
+func BestWorstWeights(best int, worst int, bestToOthers []float64, othersToWorst []float64) (*BWMResult, error) {
+	n := len(bestToOthers)
+	aBW := bestToOthers[worst]
+	result := &BWMResult{ConsistencyIndex: BestWorstConsistencyIndex(aBW)}
+
+	//PERBANDINGAN KONSISTEN (a_Bj x a_jW = a_BW), BOBOT LANGSUNG DARI VEKTOR BEST
+	consistent := true
+	for j := 0; j < n; j++ {
+		if math.Abs(bestToOthers[j]*othersToWorst[j]-aBW) > 1e-9 {
+			consistent = false
+		}
+	}
+	if consistent {
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			sum += 1 / bestToOthers[j]
+		}
+		result.Weights = make([]float64, n)
+		for j := 0; j < n; j++ {
+			result.Weights[j] = 1 / bestToOthers[j] / sum
+		}
+		return result, nil
+	}
+
+	A := make([][]float64, 0)
+	b := make([]float64, 0)
+	addRow := func(coefficients map[int]float64) {
+		row := make([]float64, n)
+		for k, v := range coefficients {
+			row[k] += v
+		}
+		A = append(A, row)
+		b = append(b, 1)
+	}
+	for j := 0; j < n; j++ {
+		if j != best {
+			addRow(map[int]float64{best: 1, j: -bestToOthers[j]})
+			addRow(map[int]float64{best: -1, j: bestToOthers[j]})
+		}
+		if j != worst {
+			addRow(map[int]float64{j: 1, worst: -othersToWorst[j]})
+			addRow(map[int]float64{j: -1, worst: othersToWorst[j]})
+		}
+	}
+
+	c := make([]float64, n)
+	for j := range c {
+		c[j] = 1
+	}
+
+	w, sum, err := simplexMaximize(c, A, b)
+	if err != nil {
+		return nil, err
+	}
+	if sum <= 0 {
+		return nil, errors.New("best worst model has no positive solution")
+	}
+
+	result.Weights = make([]float64, n)
+	for j := 0; j < n; j++ {
+		result.Weights[j] = w[j] / sum
+	}
+	result.Xi = 1 / sum
+	if result.ConsistencyIndex > 0 {
+		result.ConsistencyRatio = result.Xi / result.ConsistencyIndex
+	}
+
+	return result, nil
+}
+
+// BestWorstConsistencyIndex is the maximum ξ for a_BW on the 1..9 scale (Rezaei, 2015), interpolated between
+// integer values.
+func BestWorstConsistencyIndex(aBW float64) float64 {
+	ci := []float64{0, 0.44, 1.00, 1.63, 2.30, 3.00, 3.73, 4.47, 5.23}
+	if aBW <= 1 {
+		return 0
+	}
+	if aBW >= 9 {
+		return ci[8]
+	}
+	k := int(aBW)
+	return ci[k-1] + (aBW-float64(k))*(ci[k]-ci[k-1])
+}
+
+// RatioMatrix returns the consistent pairwise matrix w_i/w_j of the weights.
+func RatioMatrix(weights []float64) [][]float64 {
+	n := len(weights)
+	pairwise := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		pairwise[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			pairwise[i][j] = weights[i] / weights[j]
+		}
+	}
+	return pairwise
+}
+
+// simplexMaximize maximizes c·x subject to Ax <= b and x >= 0 with b >= 0, starting from the slack basis.
+// Bland's rule keeps degenerate pivots from cycling.
+func simplexMaximize(c []float64, A [][]float64, b []float64) ([]float64, float64, error) {
+	m, n := len(A), len(c)
+
+	//TABLEAU: n VARIABEL, m SLACK, KOLOM TERAKHIR RHS
+	tableau := make([][]float64, m+1)
+	for i := 0; i < m; i++ {
+		tableau[i] = make([]float64, n+m+1)
+		copy(tableau[i], A[i])
+		tableau[i][n+i] = 1
+		tableau[i][n+m] = b[i]
+	}
+	tableau[m] = make([]float64, n+m+1)
+	for j := 0; j < n; j++ {
+		tableau[m][j] = -c[j]
+	}
+
+	basis := make([]int, m)
+	for i := range basis {
+		basis[i] = n + i
+	}
+
+	for iteration := 0; iteration < 50*(n+m); iteration++ {
+		pivotCol := -1
+		for j := 0; j < n+m; j++ {
+			if tableau[m][j] < -simplexEpsilon {
+				pivotCol = j
+				break
+			}
+		}
+		if pivotCol < 0 {
+			x := make([]float64, n)
+			for i, v := range basis {
+				if v < n {
+					x[v] = tableau[i][n+m]
+				}
+			}
+			return x, tableau[m][n+m], nil
+		}
+
+		pivotRow := -1
+		ratio := math.Inf(1)
+		for i := 0; i < m; i++ {
+			if tableau[i][pivotCol] > simplexEpsilon {
+				r := tableau[i][n+m] / tableau[i][pivotCol]
+				if r < ratio-simplexEpsilon || (math.Abs(r-ratio) <= simplexEpsilon && basis[i] < basis[pivotRow]) {
+					pivotRow, ratio = i, r
+				}
+			}
+		}
+		if pivotRow < 0 {
+			return nil, 0, errors.New("linear program is unbounded")
+		}
+
+		pivot := tableau[pivotRow][pivotCol]
+		for j := range tableau[pivotRow] {
+			tableau[pivotRow][j] /= pivot
+		}
+		for i := 0; i <= m; i++ {
+			if i == pivotRow || tableau[i][pivotCol] == 0 {
+				continue
+			}
+			factor := tableau[i][pivotCol]
+			for j := range tableau[i] {
+				tableau[i][j] -= factor * tableau[pivotRow][j]
+			}
+		}
+		basis[pivotRow] = pivotCol
+	}
+
+	return nil, 0, errors.New("simplex did not converge")
+}
@@ -0,0 +1,98 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimplexMaximize(t *testing.T) {
+	//MAX 3x + 2y, x + y <= 4, x + 3y <= 6, x <= 3 DI TITIK (3, 1)
+	x, value, err := simplexMaximize([]float64{3, 2}, [][]float64{
+		{1, 1},
+		{1, 3},
+		{1, 0},
+	}, []float64{4, 6, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(value-11) > 1e-9 || math.Abs(x[0]-3) > 1e-9 || math.Abs(x[1]-1) > 1e-9 {
+		t.Errorf("expected 11 at (3, 1), got %v at %v", value, x)
+	}
+
+	if _, _, err = simplexMaximize([]float64{1, 1}, [][]float64{{1, -1}}, []float64{1}); err == nil {
+		t.Error("expected an unbounded program to fail")
+	}
+}
+
+func TestBestWorstWeightsConsistent(t *testing.T) {
+	result, err := BestWorstWeights(0, 2, []float64{1, 2, 4}, []float64{4, 2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{4.0 / 7, 2.0 / 7, 1.0 / 7}
+	for i := range expected {
+		if math.Abs(result.Weights[i]-expected[i]) > 1e-9 {
+			t.Fatalf("expected %v, got %v", expected, result.Weights)
+		}
+	}
+	if result.Xi != 0 || result.ConsistencyRatio != 0 {
+		t.Errorf("consistent judgments give ξ %v and CR %v", result.Xi, result.ConsistencyRatio)
+	}
+}
+
+func TestBestWorstWeightsMinimizesXi(t *testing.T) {
+	best, worst := 0, 2
+	bestToOthers := []float64{1, 3, 4}
+	othersToWorst := []float64{4, 2, 1}
+	result, err := BestWorstWeights(best, worst, bestToOthers, othersToWorst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deviation := func(w []float64) float64 {
+		xi := 0.0
+		for j := range w {
+			xi = math.Max(xi, math.Abs(w[best]-bestToOthers[j]*w[j]))
+			xi = math.Max(xi, math.Abs(w[j]-othersToWorst[j]*w[worst]))
+		}
+		return xi
+	}
+
+	sum := 0.0
+	for _, w := range result.Weights {
+		sum += w
+	}
+	if math.Abs(sum-1) > 1e-9 || result.Xi <= 0 {
+		t.Fatalf("expected normalized weights with ξ > 0, got %v with ξ %v", result.Weights, result.Xi)
+	}
+	if math.Abs(deviation(result.Weights)-result.Xi) > 1e-9 {
+		t.Errorf("ξ %v is not the largest deviation %v of the weights", result.Xi, deviation(result.Weights))
+	}
+
+	//TIDAK ADA BOBOT PADA GRID DENGAN ξ LEBIH KECIL
+	for a := 0.0; a <= 1; a += 0.002 {
+		for b := 0.0; a+b <= 1; b += 0.002 {
+			if xi := deviation([]float64{a, b, 1 - a - b}); xi < result.Xi-1e-9 {
+				t.Fatalf("weights (%v, %v, %v) give ξ %v below %v", a, b, 1-a-b, xi, result.Xi)
+			}
+		}
+	}
+	if math.Abs(result.ConsistencyRatio-result.Xi/BestWorstConsistencyIndex(4)) > 1e-12 {
+		t.Errorf("CR %v is not ξ over the consistency index", result.ConsistencyRatio)
+	}
+}
+
+func TestValidateBestWorst(t *testing.T) {
+	if errs := ValidateBestWorst("best_worst", 3, 0, 2, []float64{1, 2, 4}, []float64{4, 2, 1}); len(errs) > 0 {
+		t.Fatalf("expected valid judgments, got %v", errs)
+	}
+	if errs := ValidateBestWorst("best_worst", 3, 0, 0, []float64{1, 2, 4}, []float64{4, 2, 1}); len(errs) == 0 {
+		t.Error("expected best equal to worst to be rejected")
+	}
+	if errs := ValidateBestWorst("best_worst", 3, 0, 2, []float64{1, 2, 10}, []float64{4, 2, 1}); len(errs) == 0 {
+		t.Error("expected a value above 9 to be rejected")
+	}
+	if errs := ValidateBestWorst("best_worst", 3, 0, 2, []float64{1, 2, 4}, []float64{3, 2, 1}); len(errs) == 0 {
+		t.Error("expected a_BW that differs between the vectors to be rejected")
+	}
+}
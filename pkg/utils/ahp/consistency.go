@@ -0,0 +1,62 @@
+package ahp
+
+import "fmt"
+
+const ConsistencyThreshold = 0.1
+
+// InconsistentMessage is the error of judgments saved without force above the consistency threshold, subject
+// names them with their verb such as "Pairwise matrix is".
+func InconsistentMessage(subject string, cr float64) string {
+	return fmt.Sprintf("%s inconsistent (CR %.3f > %.1f), revise the judgments or set force to save anyway", subject, cr, ConsistencyThreshold)
+}
+
+func RatioIndex(n int) float64 {
+	ri := GetRatioIndex()
+	if n < 1 {
+		return 0
+	}
+	if n > len(ri) {
+		return ri[len(ri)-1]
+	}
+	return ri[n-1]
+}
+
+func LambdaMax(pairwise [][]float64, weights []float64) float64 {
+	n := len(pairwise)
+	if n == 0 {
+		return 0
+	}
+
+	//LAMBDA MAX = RATA RATA DARI (A.w)_i / w_i
+	lambda := 0.0
+	for i := 0; i < n; i++ {
+		weightedSum := 0.0
+		for j := 0; j < n; j++ {
+			weightedSum += pairwise[i][j] * weights[j]
+		}
+		if weights[i] != 0 {
+			lambda += weightedSum / weights[i]
+		}
+	}
+
+	return lambda / float64(n)
+}
+
+func ConsistencyIndex(lambdaMax float64, n int) float64 {
+	if n <= 2 {
+		return 0
+	}
+	return (lambdaMax - float64(n)) / float64(n-1)
+}
+
+func ConsistencyRatio(ci float64, n int) float64 {
+	ri := RatioIndex(n)
+	if ri == 0 {
+		return 0
+	}
+	return ci / ri
+}
+
+func IsConsistent(cr float64) bool {
+	return cr <= ConsistencyThreshold
+}
@@ -0,0 +1,165 @@
+package ahp
+
+import (
+	"math"
+	"sort"
+)
+
+type JudgmentSuggestion struct {
+	Row              int     `json:"row"`
+	Col              int     `json:"col"`
+	Value            float64 `json:"value"`
+	Suggested        float64 `json:"suggested"`
+	Deviation        float64 `json:"deviation"`
+	ConsistencyRatio float64 `json:"consistency_ratio"`
+}
+
+func SaatyScale() []float64 {
+	scale := make([]float64, 0)
+	for v := 9; v >= 2; v-- {
+		scale = append(scale, 1/float64(v))
+	}
+	for v := 1; v <= 9; v++ {
+		scale = append(scale, float64(v))
+	}
+	return scale
+}
+
+// NearestSaatyValue rounds a ratio to the closest value of the 1/9..9 scale in log space.
+func NearestSaatyValue(value float64) float64 {
+	nearest := 1.0
+	distance := math.Inf(1)
+	for _, v := range SaatyScale() {
+		d := math.Abs(math.Log(value) - math.Log(v))
+		if d < distance {
+			nearest, distance = v, d
+		}
+	}
+	return nearest
+}
+
+// ConsistencyWeights returns the crisp weights the consistency of pairwise is measured with. The fuzzy methods can
+// give a criteria a zero weight, their consistency is measured with the geometric mean of the middle values.
+func ConsistencyWeights(pairwise [][]float64, method string) ([]float64, error) {
+	if IsFuzzyMethod(method) {
+		return GeometricMeanWeights(pairwise), nil
+	}
+
+	weights, _, err := Weights(pairwise, method)
+	if err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+func MatrixConsistencyRatio(pairwise [][]float64, method string) (float64, error) {
+	weights, err := ConsistencyWeights(pairwise, method)
+	if err != nil {
+		return 0, err
+	}
+
+	n := len(pairwise)
+	return ConsistencyRatio(ConsistencyIndex(LambdaMax(pairwise, weights), n), n), nil
+}
+
+// Diagnose ranks the upper triangle judgments by their deviation from w_i/w_j, each with the nearest
+// Saaty value to w_i/w_j as suggestion and the CR of the matrix once that suggestion is accepted. The weights
+// are the ConsistencyWeights, the same the CR is computed with.
+func Diagnose(pairwise [][]float64, method string) ([]JudgmentSuggestion, error) {
+	weights, err := ConsistencyWeights(pairwise, method)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(pairwise)
+	suggestions := make([]JudgmentSuggestion, 0)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if weights[i] <= 0 || weights[j] <= 0 {
+				continue
+			}
+
+			//DEVIASI a_ij TERHADAP RASIO BOBOT w_i/w_j
+			ratio := weights[i] / weights[j]
+			e := pairwise[i][j] / ratio
+			deviation := math.Max(e, 1/e)
+
+			suggested := NearestSaatyValue(ratio)
+			if suggested == pairwise[i][j] {
+				continue
+			}
+
+			revised := ReviseJudgment(pairwise, i, j, suggested)
+			cr, err := MatrixConsistencyRatio(revised, method)
+			if err != nil {
+				return nil, err
+			}
+
+			suggestions = append(suggestions, JudgmentSuggestion{
+				Row:              i,
+				Col:              j,
+				Value:            pairwise[i][j],
+				Suggested:        suggested,
+				Deviation:        deviation,
+				ConsistencyRatio: cr,
+			})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(a, b int) bool {
+		return suggestions[a].Deviation > suggestions[b].Deviation
+	})
+
+	return suggestions, nil
+}
+
+// ReviseJudgment returns a copy of pairwise with a_ij set to value and a_ji to its reciprocal.
+func ReviseJudgment(pairwise [][]float64, i int, j int, value float64) [][]float64 {
+	revised := copyMatrix(pairwise)
+	revised[i][j] = value
+	revised[j][i] = 1 / value
+	return revised
+}
+
+// Repair applies the most deviating suggestion until the matrix is consistent, at most n*n times. consistent is
+// false when no suggestion is left or the limit is reached before the CR drops below the threshold.
+func Repair(pairwise [][]float64, method string) (repaired [][]float64, applied []JudgmentSuggestion, consistent bool, err error) {
+	repaired = copyMatrix(pairwise)
+	applied = make([]JudgmentSuggestion, 0)
+
+	n := len(pairwise)
+	for iteration := 0; iteration <= n*n; iteration++ {
+		cr, err := MatrixConsistencyRatio(repaired, method)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if IsConsistent(cr) {
+			return repaired, applied, true, nil
+		}
+		if iteration == n*n {
+			break
+		}
+
+		suggestions, err := Diagnose(repaired, method)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if len(suggestions) == 0 {
+			break
+		}
+
+		suggestion := suggestions[0]
+		repaired = ReviseJudgment(repaired, suggestion.Row, suggestion.Col, suggestion.Suggested)
+		applied = append(applied, suggestion)
+	}
+
+	return repaired, applied, false, nil
+}
+
+func copyMatrix(pairwise [][]float64) [][]float64 {
+	copied := make([][]float64, len(pairwise))
+	for i, row := range pairwise {
+		copied[i] = append([]float64{}, row...)
+	}
+	return copied
+}
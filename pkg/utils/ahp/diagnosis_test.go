@@ -0,0 +1,52 @@
+package ahp
+
+import "testing"
+
+var inconsistentPairwise = [][]float64{
+	{1, 9, 1.0 / 9},
+	{1.0 / 9, 1, 9},
+	{9, 1.0 / 9, 1},
+}
+
+func TestDiagnoseUsesConsistencyWeights(t *testing.T) {
+	for _, method := range []string{MethodEigenvector, MethodFuzzyExtent, MethodFuzzyGeometricMean} {
+		weights, err := ConsistencyWeights(inconsistentPairwise, method)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		suggestions, err := Diagnose(inconsistentPairwise, method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(suggestions) == 0 {
+			t.Fatalf("%s: expected suggestions", method)
+		}
+		for _, suggestion := range suggestions {
+			expected := NearestSaatyValue(weights[suggestion.Row] / weights[suggestion.Col])
+			if suggestion.Suggested != expected {
+				t.Errorf("%s: [%d][%d] suggested %v, the consistency weights give %v",
+					method, suggestion.Row, suggestion.Col, suggestion.Suggested, expected)
+			}
+		}
+	}
+}
+
+func TestRepairReportsConsistency(t *testing.T) {
+	repaired, applied, consistent, err := Repair(inconsistentPairwise, MethodEigenvector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr, err := MatrixConsistencyRatio(repaired, MethodEigenvector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consistent != IsConsistent(cr) || len(applied) == 0 {
+		t.Errorf("consistent %v with CR %v after %d revisions", consistent, cr, len(applied))
+	}
+
+	_, applied, consistent, err = Repair([][]float64{{1, 2}, {0.5, 1}}, MethodEigenvector)
+	if err != nil || !consistent || len(applied) != 0 {
+		t.Errorf("a consistent matrix needs no revision, got %v %v %v", applied, consistent, err)
+	}
+}
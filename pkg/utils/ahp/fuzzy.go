@@ -0,0 +1,230 @@
+package ahp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	MethodFuzzyExtent        = "fuzzy_extent"
+	MethodFuzzyGeometricMean = "fuzzy_geometric_mean"
+)
+
+// TFN is a triangular fuzzy number (l, m, u).
+type TFN [3]float64
+
+func IsFuzzyMethod(method string) bool {
+	return method == MethodFuzzyExtent || method == MethodFuzzyGeometricMean
+}
+
+// FuzzyTerms are the linguistic terms of the Saaty values 1..9 in order.
+var FuzzyTerms = []string{"equal", "equal_moderate", "moderate", "moderate_strong", "strong", "strong_very_strong",
+	"very_strong", "very_strong_extreme", "extreme"}
+
+// FuzzyScale maps every linguistic term to the TFN of its Saaty value, a term and its number give the same TFN.
+func FuzzyScale() map[string]TFN {
+	scale := make(map[string]TFN)
+	for k, term := range FuzzyTerms {
+		scale[term] = CrispToTFN(float64(k + 1))
+	}
+	return scale
+}
+
+// CrispToTFN spreads a Saaty value one step on both sides within 1..9 (Chang), so 9 is (8, 9, 9), reciprocals
+// get the inverted TFN.
+func CrispToTFN(value float64) TFN {
+	if value == 1 {
+		return TFN{1, 1, 1}
+	}
+	if value < 1 {
+		return CrispToTFN(1 / value).Inverse()
+	}
+	return TFN{math.Max(1, value-1), value, math.Min(9, value+1)}
+}
+
+func LinguisticToTFN(term string) (TFN, error) {
+	key := strings.ToLower(strings.TrimSpace(term))
+	inverse := strings.HasPrefix(key, "1/")
+	key = strings.TrimPrefix(key, "1/")
+
+	tfn, ok := FuzzyScale()[key]
+	if !ok {
+		return TFN{}, fmt.Errorf("unknown linguistic term %s", term)
+	}
+	if inverse {
+		return tfn.Inverse(), nil
+	}
+	return tfn, nil
+}
+
+// UnmarshalJSON accepts [l, m, u], a crisp Saaty value or a linguistic term such as "strong" or "1/strong".
+func (t *TFN) UnmarshalJSON(b []byte) error {
+	var values []float64
+	if err := json.Unmarshal(b, &values); err == nil {
+		if len(values) != 3 {
+			return fmt.Errorf("triangular fuzzy number needs 3 values, got %d", len(values))
+		}
+		if values[0] <= 0 || values[0] > values[1] || values[1] > values[2] {
+			return fmt.Errorf("triangular fuzzy number must satisfy 0 < l <= m <= u, got %v", values)
+		}
+		*t = TFN{values[0], values[1], values[2]}
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(b, &value); err == nil {
+		if value <= 0 {
+			return fmt.Errorf("pairwise value must be positive, got %v", value)
+		}
+		*t = CrispToTFN(value)
+		return nil
+	}
+
+	var term string
+	if err := json.Unmarshal(b, &term); err != nil {
+		return fmt.Errorf("triangular fuzzy number must be an array, a number or a linguistic term")
+	}
+	tfn, err := LinguisticToTFN(term)
+	if err != nil {
+		return err
+	}
+	*t = tfn
+	return nil
+}
+
+func (t TFN) Add(o TFN) TFN {
+	return TFN{t[0] + o[0], t[1] + o[1], t[2] + o[2]}
+}
+
+func (t TFN) Mul(o TFN) TFN {
+	return TFN{t[0] * o[0], t[1] * o[1], t[2] * o[2]}
+}
+
+func (t TFN) Inverse() TFN {
+	return TFN{1 / t[2], 1 / t[1], 1 / t[0]}
+}
+
+// Defuzzify returns the centroid of the triangle.
+func (t TFN) Defuzzify() float64 {
+	return (t[0] + t[1] + t[2]) / 3
+}
+
+func FuzzifyMatrix(pairwise [][]float64) [][]TFN {
+	fuzzy := make([][]TFN, len(pairwise))
+	for i, row := range pairwise {
+		fuzzy[i] = make([]TFN, len(row))
+		for j, value := range row {
+			fuzzy[i][j] = CrispToTFN(value)
+		}
+	}
+	return fuzzy
+}
+
+func MiddleMatrix(pairwise [][]TFN) [][]float64 {
+	middle := make([][]float64, len(pairwise))
+	for i, row := range pairwise {
+		middle[i] = make([]float64, len(row))
+		for j, value := range row {
+			middle[i][j] = value[1]
+		}
+	}
+	return middle
+}
+
+func FuzzyWeights(pairwise [][]TFN, method string) ([]TFN, []float64, error) {
+	switch method {
+	case MethodFuzzyExtent:
+		fuzzyWeights, weights := ExtentWeights(pairwise)
+		return fuzzyWeights, weights, nil
+	case MethodFuzzyGeometricMean:
+		fuzzyWeights, weights := BuckleyWeights(pairwise)
+		return fuzzyWeights, weights, nil
+	}
+	return nil, nil, fmt.Errorf("unknown fuzzy weighting method %s", method)
+}
+
+// ExtentWeights derives the weights with Chang's extent analysis.
+func ExtentWeights(pairwise [][]TFN) ([]TFN, []float64) {
+	n := len(pairwise)
+
+	//MENCARI FUZZY SYNTHETIC EXTENT SETIAP BARIS
+	rowSum := make([]TFN, n)
+	total := TFN{}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			rowSum[i] = rowSum[i].Add(pairwise[i][j])
+		}
+		total = total.Add(rowSum[i])
+	}
+
+	extents := make([]TFN, n)
+	for i := range extents {
+		extents[i] = rowSum[i].Mul(total.Inverse())
+	}
+
+	//DERAJAT KEMUNGKINAN MINIMUM TERHADAP EXTENT LAIN
+	weights := make([]float64, n)
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		weights[i] = 1
+		for k := 0; k < n; k++ {
+			if k != i {
+				weights[i] = math.Min(weights[i], possibility(extents[i], extents[k]))
+			}
+		}
+		sum += weights[i]
+	}
+
+	for i := range weights {
+		weights[i] /= sum
+	}
+
+	return extents, weights
+}
+
+// possibility is the degree V(a >= b).
+func possibility(a TFN, b TFN) float64 {
+	if a[1] >= b[1] {
+		return 1
+	}
+	if b[0] >= a[2] {
+		return 0
+	}
+	return (b[0] - a[2]) / ((a[1] - a[2]) - (b[1] - b[0]))
+}
+
+// BuckleyWeights derives the weights with Buckley's fuzzy geometric mean, defuzzified by centroid.
+func BuckleyWeights(pairwise [][]TFN) ([]TFN, []float64) {
+	n := len(pairwise)
+
+	//RATA RATA GEOMETRIS FUZZY SETIAP BARIS
+	means := make([]TFN, n)
+	total := TFN{}
+	for i := 0; i < n; i++ {
+		product := TFN{1, 1, 1}
+		for j := 0; j < n; j++ {
+			product = product.Mul(pairwise[i][j])
+		}
+		for k := range product {
+			means[i][k] = math.Pow(product[k], 1/float64(n))
+		}
+		total = total.Add(means[i])
+	}
+
+	fuzzyWeights := make([]TFN, n)
+	weights := make([]float64, n)
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		fuzzyWeights[i] = means[i].Mul(total.Inverse())
+		weights[i] = fuzzyWeights[i].Defuzzify()
+		sum += weights[i]
+	}
+
+	for i := range weights {
+		weights[i] /= sum
+	}
+
+	return fuzzyWeights, weights
+}
@@ -0,0 +1,73 @@
+package ahp
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFuzzyScaleMatchesCrispValues(t *testing.T) {
+	for k, term := range FuzzyTerms {
+		var fromTerm, fromNumber TFN
+		if err := json.Unmarshal([]byte(`"`+term+`"`), &fromTerm); err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(float64(k + 1))
+		if err := json.Unmarshal(b, &fromNumber); err != nil {
+			t.Fatal(err)
+		}
+		if fromTerm != fromNumber {
+			t.Errorf("%s gives %v but %d gives %v", term, fromTerm, k+1, fromNumber)
+		}
+	}
+
+	if extreme := FuzzyScale()["extreme"]; extreme != (TFN{8, 9, 9}) {
+		t.Errorf("expected extreme to be (8, 9, 9), got %v", extreme)
+	}
+}
+
+// fuzzyExample compares three criteria with row sums (7, 9, 11), (2.25, 3.333, 4.5), (1.5, 1.7, 2.25) and a total
+// of (10.75, 14.033, 17.75).
+var fuzzyExample = [][]TFN{
+	{{1, 1, 1}, {2, 3, 4}, {4, 5, 6}},
+	{{1.0 / 4, 1.0 / 3, 1.0 / 2}, {1, 1, 1}, {1, 2, 3}},
+	{{1.0 / 6, 1.0 / 5, 1.0 / 4}, {1.0 / 3, 1.0 / 2, 1}, {1, 1, 1}},
+}
+
+func TestExtentWeights(t *testing.T) {
+	extents, weights := ExtentWeights(fuzzyExample)
+
+	//S_i = ROW SUM x (1/17.75, 1/14.033, 1/10.75)
+	middle := 9 + 10.0/3 + 1.7
+	expectedExtents := []TFN{
+		{7 / 17.75, 9 / middle, 11 / 10.75},
+		{2.25 / 17.75, (10.0 / 3) / middle, 4.5 / 10.75},
+		{1.5 / 17.75, 1.7 / middle, 2.25 / 10.75},
+	}
+	for i := range expectedExtents {
+		for k := range expectedExtents[i] {
+			if math.Abs(extents[i][k]-expectedExtents[i][k]) > 1e-9 {
+				t.Fatalf("expected extents %v, got %v", expectedExtents, extents)
+			}
+		}
+	}
+
+	//d = (1, V(S_2 >= S_1) = 0.0566, V(S_3 >= S_1) = 0), DINORMALISASI
+	expected := []float64{0.946408, 0.053592, 0}
+	for i := range expected {
+		if math.Abs(weights[i]-expected[i]) > 1e-6 {
+			t.Fatalf("expected %v, got %v", expected, weights)
+		}
+	}
+}
+
+func TestBuckleyWeights(t *testing.T) {
+	_, weights := BuckleyWeights(fuzzyExample)
+
+	expected := []float64{0.637364, 0.233279, 0.129357}
+	for i := range expected {
+		if math.Abs(weights[i]-expected[i]) > 1e-6 {
+			t.Fatalf("expected %v, got %v", expected, weights)
+		}
+	}
+}
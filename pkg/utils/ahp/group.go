@@ -0,0 +1,140 @@
+package ahp
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	AggregationAIJ = "aij"
+	AggregationAIP = "aip"
+)
+
+// NormalizeExpertWeights scales the expert weights to sum to one. The total has to be positive and either every
+// expert or none has a zero weight, with no weights at all every expert counts the same.
+func NormalizeExpertWeights(weights []float64) ([]float64, error) {
+	normalized := make([]float64, len(weights))
+
+	sum := 0.0
+	zeros := 0
+	for i, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("expert %d has an invalid weight %v", i+1, weight)
+		}
+		if weight == 0 {
+			zeros++
+		}
+		sum += weight
+	}
+
+	//TANPA BOBOT PAKAR, SEMUA PAKAR DIANGGAP SETARA
+	if zeros == len(weights) {
+		for i := range weights {
+			normalized[i] = 1 / float64(len(weights))
+		}
+		return normalized, nil
+	}
+	if zeros > 0 {
+		return nil, fmt.Errorf("%d of %d experts have a zero weight, give every expert a positive weight", zeros, len(weights))
+	}
+
+	for i, weight := range weights {
+		normalized[i] = weight / sum
+	}
+
+	return normalized, nil
+}
+
+// AggregateJudgments combines the experts matrices cell by cell with the weighted geometric mean (AIJ).
+func AggregateJudgments(matrices [][][]float64, expertWeights []float64) ([][]float64, error) {
+	if len(matrices) == 0 {
+		return nil, fmt.Errorf("no judgments to aggregate")
+	}
+
+	if len(expertWeights) != len(matrices) {
+		return nil, fmt.Errorf("%d expert weights for %d judgments", len(expertWeights), len(matrices))
+	}
+
+	n := len(matrices[0])
+	for k, pairwise := range matrices {
+		if len(pairwise) != n {
+			return nil, fmt.Errorf("judgment %d has %d rows but %d are expected", k+1, len(pairwise), n)
+		}
+		for i, row := range pairwise {
+			if len(row) != n {
+				return nil, fmt.Errorf("judgment %d row %d has %d columns but %d are expected", k+1, i+1, len(row), n)
+			}
+			for j, value := range row {
+				if value <= 0 {
+					return nil, fmt.Errorf("judgment %d cell [%d][%d] must be positive", k+1, i+1, j+1)
+				}
+			}
+		}
+	}
+
+	weights, err := NormalizeExpertWeights(expertWeights)
+	if err != nil {
+		return nil, err
+	}
+	aggregated := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		aggregated[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			logSum := 0.0
+			for k, pairwise := range matrices {
+				logSum += weights[k] * math.Log(pairwise[i][j])
+			}
+			aggregated[i][j] = math.Exp(logSum)
+		}
+	}
+
+	return aggregated, nil
+}
+
+// AggregatePriorities combines the experts priority vectors with the weighted arithmetic mean (AIP).
+func AggregatePriorities(priorities [][]float64, expertWeights []float64) ([]float64, error) {
+	if len(priorities) == 0 {
+		return nil, fmt.Errorf("no priorities to aggregate")
+	}
+
+	if len(expertWeights) != len(priorities) {
+		return nil, fmt.Errorf("%d expert weights for %d priorities", len(expertWeights), len(priorities))
+	}
+
+	n := len(priorities[0])
+	weights, err := NormalizeExpertWeights(expertWeights)
+	if err != nil {
+		return nil, err
+	}
+	aggregated := make([]float64, n)
+	for k, priority := range priorities {
+		if len(priority) != n {
+			return nil, fmt.Errorf("priority %d has %d elements but %d are expected", k+1, len(priority), n)
+		}
+		for i := range priority {
+			aggregated[i] += weights[k] * priority[i]
+		}
+	}
+
+	return aggregated, nil
+}
+
+// PriorityMatrix returns the perfectly consistent matrix w[i]/w[j] that reproduces the given weights, every weight
+// has to be positive.
+func PriorityMatrix(weights []float64) ([][]float64, error) {
+	n := len(weights)
+	for i, weight := range weights {
+		if weight <= 0 {
+			return nil, fmt.Errorf("priority %d must be positive", i+1)
+		}
+	}
+
+	pairwise := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		pairwise[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			pairwise[i][j] = weights[i] / weights[j]
+		}
+	}
+	return pairwise, nil
+}
@@ -0,0 +1,56 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAggregateJudgmentsRejectsInvalidInput(t *testing.T) {
+	valid := [][]float64{{1, 3}, {1.0 / 3, 1}}
+	ragged := [][]float64{{1, 3}, {1.0 / 3}}
+
+	cases := map[string]struct {
+		matrices [][][]float64
+		weights  []float64
+	}{
+		"ragged matrix":      {[][][]float64{valid, ragged}, []float64{1, 1}},
+		"negative weight":    {[][][]float64{valid, valid}, []float64{1, -1}},
+		"mixed zero weights": {[][][]float64{valid, valid}, []float64{0, 1}},
+		"missing weight":     {[][][]float64{valid, valid}, []float64{1}},
+	}
+	for name, c := range cases {
+		if _, err := AggregateJudgments(c.matrices, c.weights); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAggregateJudgmentsWeightedGeometricMean(t *testing.T) {
+	aggregated, err := AggregateJudgments([][][]float64{
+		{{1, 2}, {0.5, 1}},
+		{{1, 8}, {0.125, 1}},
+	}, []float64{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(aggregated[0][1]-4) > 1e-9 || math.Abs(aggregated[1][0]-0.25) > 1e-9 {
+		t.Errorf("expected 4 and 1/4, got %v", aggregated)
+	}
+
+	aggregated, err = AggregateJudgments([][][]float64{
+		{{1, 2}, {0.5, 1}},
+		{{1, 8}, {0.125, 1}},
+	}, []float64{3, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := math.Pow(2, 0.75) * math.Pow(8, 0.25); math.Abs(aggregated[0][1]-expected) > 1e-9 {
+		t.Errorf("expected %v, got %v", expected, aggregated[0][1])
+	}
+}
+
+func TestPriorityMatrixRejectsZeroWeight(t *testing.T) {
+	if _, err := PriorityMatrix([]float64{0.5, 0, 0.5}); err == nil {
+		t.Error("expected an error")
+	}
+}
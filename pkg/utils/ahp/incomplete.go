@@ -0,0 +1,213 @@
+package ahp
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	CompletionHarker = "harker"
+	CompletionLLSM   = "llsm"
+)
+
+type MatrixCell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Reciprocate fills every missing cell whose mirror is known with the reciprocal, cells missing on both
+// sides stay nil.
+func Reciprocate(pairwise [][]*float64) [][]*float64 {
+	n := len(pairwise)
+	filled := make([][]*float64, n)
+	for i := 0; i < n; i++ {
+		filled[i] = make([]*float64, n)
+		for j := 0; j < n; j++ {
+			switch {
+			case i == j:
+				one := 1.0
+				filled[i][j] = &one
+			case pairwise[i][j] != nil:
+				value := *pairwise[i][j]
+				filled[i][j] = &value
+			case pairwise[j][i] != nil:
+				value := 1 / *pairwise[j][i]
+				filled[i][j] = &value
+			}
+		}
+	}
+	return filled
+}
+
+// IsConnected reports whether every criteria is reachable from the first through known comparisons.
+func IsConnected(pairwise [][]*float64) bool {
+	n := len(pairwise)
+	if n == 0 {
+		return false
+	}
+
+	visited := make([]bool, n)
+	visited[0] = true
+	queue := []int{0}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for j := 0; j < n; j++ {
+			if !visited[j] && (pairwise[i][j] != nil || pairwise[j][i] != nil) {
+				visited[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	for _, v := range visited {
+		if !v {
+			return false
+		}
+	}
+	return true
+}
+
+// Complete estimates every cell missing on both sides as w_i/w_j with weights from Harker's method or
+// the logarithmic least squares method.
+func Complete(pairwise [][]*float64, method string) ([][]float64, []MatrixCell, error) {
+	for i, row := range pairwise {
+		for j, value := range row {
+			if value != nil && *value <= 0 {
+				return nil, nil, fmt.Errorf("pairwise value at row %d col %d must be positive", i+1, j+1)
+			}
+		}
+	}
+
+	filled := Reciprocate(pairwise)
+	if !IsConnected(filled) {
+		return nil, nil, fmt.Errorf("pairwise comparisons are not connected, every criteria must be compared directly or indirectly with the others")
+	}
+
+	var weights []float64
+	switch method {
+	case "", CompletionHarker:
+		weights = HarkerWeights(filled)
+	case CompletionLLSM:
+		weights = LLSMWeights(filled)
+	default:
+		return nil, nil, fmt.Errorf("unknown completion method %s", method)
+	}
+
+	n := len(filled)
+	completed := make([][]float64, n)
+	estimated := make([]MatrixCell, 0)
+	for i := 0; i < n; i++ {
+		completed[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			if filled[i][j] != nil {
+				completed[i][j] = *filled[i][j]
+				continue
+			}
+			completed[i][j] = weights[i] / weights[j]
+			estimated = append(estimated, MatrixCell{Row: i, Col: j})
+		}
+	}
+
+	return completed, estimated, nil
+}
+
+// HarkerWeights takes the principal eigenvector of the matrix where missing cells are 0 and every
+// diagonal cell is 1 plus the number of missing cells in its row.
+func HarkerWeights(pairwise [][]*float64) []float64 {
+	n := len(pairwise)
+	harker := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		harker[i] = make([]float64, n)
+		missing := 0
+		for j := 0; j < n; j++ {
+			if i != j && pairwise[i][j] == nil {
+				missing++
+				continue
+			}
+			if i != j {
+				harker[i][j] = *pairwise[i][j]
+			}
+		}
+		harker[i][i] = 1 + float64(missing)
+	}
+
+	weights, _ := EigenvectorWeights(harker, EigenvectorTolerance, EigenvectorMaxIteration)
+	return weights
+}
+
+// LLSMWeights minimizes the squared log error over the known cells by solving (L + 11ᵀ) v = b, with L the
+// Laplacian of the comparison graph, then normalizes exp(v).
+func LLSMWeights(pairwise [][]*float64) []float64 {
+	n := len(pairwise)
+	a := make([][]float64, n)
+	b := make([]float64, n)
+	for i := 0; i < n; i++ {
+		a[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			a[i][j] = 1
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j || pairwise[i][j] == nil {
+				continue
+			}
+			a[i][i]++
+			a[i][j]--
+			b[i] += math.Log(*pairwise[i][j])
+		}
+	}
+
+	v := solve(a, b)
+
+	weights := make([]float64, n)
+	sum := 0.0
+	for i := range v {
+		weights[i] = math.Exp(v[i])
+		sum += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+
+	return weights
+}
+
+// solve runs gaussian elimination with partial pivoting.
+func solve(a [][]float64, b []float64) []float64 {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range a {
+		m[i] = append(append([]float64{}, a[i]...), b[i])
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := m[row][col] / m[col][col]
+			for k := col; k <= n; k++ {
+				m[row][k] -= factor * m[col][k]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := m[i][n]
+		for k := i + 1; k < n; k++ {
+			sum -= m[i][k] * x[k]
+		}
+		x[i] = sum / m[i][i]
+	}
+
+	return x
+}
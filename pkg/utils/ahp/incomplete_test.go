@@ -0,0 +1,74 @@
+package ahp
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// incompleteExample misses the comparison of the first and the last criteria, its LLSM completion is the
+// geometric mean of the two paths 2·3 and 3·1, 3√2.
+func incompleteExample() [][]*float64 {
+	return [][]*float64{
+		{cell(1), cell(2), cell(3), nil},
+		{cell(1.0 / 2), cell(1), cell(2), cell(3)},
+		{cell(1.0 / 3), cell(1.0 / 2), cell(1), cell(1)},
+		{nil, cell(1.0 / 3), cell(1), cell(1)},
+	}
+}
+
+func TestCompleteReferenceValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		pairwise  [][]*float64
+		method    string
+		weights   []float64
+		completed float64
+	}{
+		{"consistent harker", [][]*float64{{cell(1), cell(2), nil}, {nil, cell(1), cell(2)}, {nil, nil, cell(1)}}, CompletionHarker, []float64{4.0 / 7, 2.0 / 7, 1.0 / 7}, 4},
+		{"consistent llsm", [][]*float64{{cell(1), cell(2), nil}, {nil, cell(1), cell(2)}, {nil, nil, cell(1)}}, CompletionLLSM, []float64{4.0 / 7, 2.0 / 7, 1.0 / 7}, 4},
+		{"harker", incompleteExample(), CompletionHarker, []float64{0.474025, 0.278547, 0.135145, 0.112282}, 4.221728},
+		{"llsm", incompleteExample(), CompletionLLSM, []float64{0.474818, 0.278202, 0.135065, 0.111916}, 3 * math.Sqrt2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var weights []float64
+			if tt.method == CompletionHarker {
+				weights = HarkerWeights(Reciprocate(tt.pairwise))
+			} else {
+				weights = LLSMWeights(Reciprocate(tt.pairwise))
+			}
+			for i := range tt.weights {
+				if math.Abs(weights[i]-tt.weights[i]) > 1e-6 {
+					t.Errorf("expected weights %v, got %v", tt.weights, weights)
+					break
+				}
+			}
+
+			completed, estimated, err := Complete(tt.pairwise, tt.method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			last := len(completed) - 1
+			if math.Abs(completed[0][last]-tt.completed) > 1e-6 || math.Abs(completed[last][0]*tt.completed-1) > 1e-6 {
+				t.Errorf("expected the missing cell to be completed as %v, got %v", tt.completed, completed[0][last])
+			}
+			if !reflect.DeepEqual(estimated, []MatrixCell{{Row: 0, Col: last}, {Row: last, Col: 0}}) {
+				t.Errorf("expected only the missing pair to be estimated, got %v", estimated)
+			}
+		})
+	}
+}
+
+func TestCompleteRejectsDisconnectedComparisons(t *testing.T) {
+	pairwise := [][]*float64{
+		{cell(1), cell(2), nil, nil},
+		{nil, cell(1), nil, nil},
+		{nil, nil, cell(1), cell(3)},
+		{nil, nil, nil, cell(1)},
+	}
+	if _, _, err := Complete(pairwise, CompletionHarker); err == nil {
+		t.Error("expected two separate groups of criteria to be rejected")
+	}
+}
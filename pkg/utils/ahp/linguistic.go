@@ -0,0 +1,132 @@
+package ahp
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	ScaleSaaty     = "saaty"
+	ScaleBalanced  = "balanced"
+	ScaleGeometric = "geometric"
+)
+
+type LinguisticJudgment struct {
+	Row   int     `json:"row" example:"0"`
+	Col   int     `json:"col" example:"1"`
+	Term  string  `json:"term" example:"jelas lebih penting"`
+	Value float64 `json:"value,omitempty" example:"5"`
+}
+
+// LinguisticTerms lists the judgments of level 1..9, "row <term> col", the even levels sit between two terms.
+func LinguisticTerms() []string {
+	return []string{
+		"sama penting",
+		"antara sama penting dan sedikit lebih penting",
+		"sedikit lebih penting",
+		"antara sedikit lebih penting dan jelas lebih penting",
+		"jelas lebih penting",
+		"antara jelas lebih penting dan sangat jelas lebih penting",
+		"sangat jelas lebih penting",
+		"antara sangat jelas lebih penting dan mutlak lebih penting",
+		"mutlak lebih penting",
+	}
+}
+
+// ScaleValues returns the value of level 1..9 on the saaty scale (k), the balanced scale (w/(1-w) with
+// w = (9+k)/20, i.e. (9+k)/(11-k)) or a geometric scale (9^((k-1)/8)). Every scale ends at 9 so the
+// consistency ratio can use the random index of Saaty, the geometric scale of Lootsma (2^((k-1)/2)) would
+// reach 16.
+func ScaleValues(scale string) ([]float64, error) {
+	values := make([]float64, 9)
+	for k := 1; k <= 9; k++ {
+		switch scale {
+		case "", ScaleSaaty:
+			values[k-1] = float64(k)
+		case ScaleBalanced:
+			values[k-1] = float64(9+k) / float64(11-k)
+		case ScaleGeometric:
+			values[k-1] = math.Pow(9, float64(k-1)/8)
+		default:
+			return nil, fmt.Errorf("unknown scale %s", scale)
+		}
+	}
+	return values, nil
+}
+
+func LinguisticValue(term string, scale string) (float64, error) {
+	values, err := ScaleValues(scale)
+	if err != nil {
+		return 0, err
+	}
+
+	key := strings.Join(strings.Fields(strings.ToLower(term)), " ")
+	for k, t := range LinguisticTerms() {
+		if t == key {
+			return values[k], nil
+		}
+	}
+	return 0, fmt.Errorf("unknown linguistic term %s", term)
+}
+
+// MatrixFromLinguistic builds the reciprocal n×n matrix from "row <term> col" judgments valued on the given
+// scale, pairs that are not judged stay missing.
+func MatrixFromLinguistic(field string, n int, judgments []LinguisticJudgment, scale string) ([][]*float64, []InputError) {
+	if _, err := ScaleValues(scale); err != nil {
+		return nil, []InputError{{Field: "scale", Message: err.Error()}}
+	}
+
+	errs := make([]InputError, 0)
+	values := make([]Judgment, len(judgments))
+	for k, judgment := range judgments {
+		value, err := LinguisticValue(judgment.Term, scale)
+		if err != nil {
+			errs = append(errs, InputError{Field: fmt.Sprintf("%s[%d].term", field, k), Message: err.Error()})
+			continue
+		}
+		values[k] = Judgment{Row: judgment.Row, Col: judgment.Col, Value: value}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	//NILAI SUDAH BERASAL DARI SKALA, RENTANG 1/9..9 TIDAK DICEK
+	return judgmentMatrix(field, n, values, false)
+}
+
+// Linguistic describes every upper triangle pair with the term of the nearest scale level, oriented so the
+// row is the more important criteria.
+func Linguistic(pairwise [][]float64, scale string) ([]LinguisticJudgment, error) {
+	values, err := ScaleValues(scale)
+	if err != nil {
+		return nil, err
+	}
+	terms := LinguisticTerms()
+
+	judgments := make([]LinguisticJudgment, 0)
+	for i := 0; i < len(pairwise); i++ {
+		for j := i + 1; j < len(pairwise); j++ {
+			row, col, value := i, j, pairwise[i][j]
+			if value <= 0 {
+				continue
+			}
+			if value < 1 {
+				row, col, value = j, i, 1/value
+			}
+
+			level := 0
+			distance := math.Inf(1)
+			for k, v := range values {
+				d := math.Abs(math.Log(value) - math.Log(v))
+				if d < distance {
+					level, distance = k, d
+				}
+			}
+
+			judgments = append(judgments, LinguisticJudgment{Row: row, Col: col, Term: terms[level], Value: value})
+		}
+	}
+
+	return judgments, nil
+}
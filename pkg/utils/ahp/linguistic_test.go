@@ -0,0 +1,23 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScaleValuesEndAtNine(t *testing.T) {
+	for _, scale := range []string{ScaleSaaty, ScaleBalanced, ScaleGeometric} {
+		values, err := ScaleValues(scale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if values[0] != 1 || math.Abs(values[8]-9) > 1e-9 {
+			t.Errorf("%s: expected 1..9, got %v..%v", scale, values[0], values[8])
+		}
+		for k := 1; k < len(values); k++ {
+			if values[k] <= values[k-1] {
+				t.Errorf("%s: level %d is not above level %d", scale, k+1, k)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package ahp
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	PreferenceUsual    = "usual"
+	PreferenceUShape   = "u_shape"
+	PreferenceVShape   = "v_shape"
+	PreferenceLinear   = "linear"
+	PreferenceGaussian = "gaussian"
+)
+
+// PreferenceFunction turns the difference d between two alternatives on one criteria into a preference in
+// [0, 1]. Q is the indifference threshold, P the strict preference threshold and S the gaussian inflection.
+type PreferenceFunction struct {
+	Type string  `json:"type" example:"linear"`
+	Q    float64 `json:"q" example:"0.05"`
+	P    float64 `json:"p" example:"0.2"`
+	S    float64 `json:"s" example:"0.1"`
+}
+
+type PrometheeFlow struct {
+	Positive float64 `json:"positive"`
+	Negative float64 `json:"negative"`
+	Net      float64 `json:"net"`
+}
+
+func (f PreferenceFunction) Validate() error {
+	switch f.Type {
+	case "", PreferenceUsual:
+	case PreferenceUShape:
+		if f.Q < 0 {
+			return fmt.Errorf("u_shape needs q >= 0")
+		}
+	case PreferenceVShape:
+		if f.P <= 0 {
+			return fmt.Errorf("v_shape needs p > 0")
+		}
+	case PreferenceLinear:
+		if f.Q < 0 || f.P <= f.Q {
+			return fmt.Errorf("linear needs 0 <= q < p")
+		}
+	case PreferenceGaussian:
+		if f.S <= 0 {
+			return fmt.Errorf("gaussian needs s > 0")
+		}
+	default:
+		return fmt.Errorf("unknown preference function %s", f.Type)
+	}
+	return nil
+}
+
+func (f PreferenceFunction) Preference(d float64) float64 {
+	if d <= 0 {
+		return 0
+	}
+
+	switch f.Type {
+	case PreferenceUShape:
+		if d > f.Q {
+			return 1
+		}
+		return 0
+	case PreferenceVShape:
+		return math.Min(1, d/f.P)
+	case PreferenceLinear:
+		switch {
+		case d <= f.Q:
+			return 0
+		case d > f.P:
+			return 1
+		}
+		return (d - f.Q) / (f.P - f.Q)
+	case PreferenceGaussian:
+		return 1 - math.Exp(-d*d/(2*f.S*f.S))
+	}
+	return 1
+}
+
+// PrometheeFlows computes the PROMETHEE II leaving, entering and net flow of every alternative (rows), the
+// weights are normalized and a cost criteria prefers the lowest value.
+func PrometheeFlows(matrix [][]float64, weights []float64, impacts []string, functions []PreferenceFunction) ([]PrometheeFlow, error) {
+	n, m := len(matrix), len(weights)
+	if len(impacts) != m || len(functions) != m {
+		return nil, fmt.Errorf("%d impacts and %d preference functions given for %d criteria", len(impacts), len(functions), m)
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("criteria weights must sum to a positive value")
+	}
+
+	sign := make([]float64, m)
+	for j := 0; j < m; j++ {
+		if err := functions[j].Validate(); err != nil {
+			return nil, fmt.Errorf("criteria %d: %s", j, err.Error())
+		}
+		switch impacts[j] {
+		case "", ImpactBenefit:
+			sign[j] = 1
+		case ImpactCost:
+			sign[j] = -1
+		default:
+			return nil, fmt.Errorf("unknown impact %s", impacts[j])
+		}
+	}
+
+	flows := make([]PrometheeFlow, n)
+	if n < 2 {
+		return flows, nil
+	}
+
+	//INDEKS PREFERENSI AGREGAT SETIAP PASANG ALTERNATIF
+	for a := 0; a < n; a++ {
+		for b := 0; b < n; b++ {
+			if a == b {
+				continue
+			}
+			pi := 0.0
+			for j := 0; j < m; j++ {
+				pi += weights[j] / total * functions[j].Preference(sign[j]*(matrix[a][j]-matrix[b][j]))
+			}
+			flows[a].Positive += pi
+			flows[b].Negative += pi
+		}
+	}
+
+	for a := range flows {
+		flows[a].Positive /= float64(n - 1)
+		flows[a].Negative /= float64(n - 1)
+		flows[a].Net = flows[a].Positive - flows[a].Negative
+	}
+
+	return flows, nil
+}
@@ -0,0 +1,63 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrometheeFlowsReferenceValues(t *testing.T) {
+	matrix, weights, impacts := fighterExample()
+	functions := make([]PreferenceFunction, len(weights))
+	for j := range functions {
+		functions[j] = PreferenceFunction{Type: PreferenceUsual}
+	}
+
+	flows, err := PrometheeFlows(matrix, weights, impacts, functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//KRITERIA USUAL: FLOW = JUMLAH BOBOT KRITERIA YANG LEBIH BAIK / (n - 1)
+	expected := []PrometheeFlow{
+		{Positive: 1.0 / 2, Negative: 2.0 / 5, Net: 1.0 / 10},
+		{Positive: 3.0 / 10, Negative: 3.0 / 5, Net: -3.0 / 10},
+		{Positive: 2.0 / 3, Negative: 1.0 / 3, Net: 1.0 / 3},
+		{Positive: 1.0 / 3, Negative: 7.0 / 15, Net: -2.0 / 15},
+	}
+	for i := range expected {
+		if math.Abs(flows[i].Positive-expected[i].Positive) > 1e-9 ||
+			math.Abs(flows[i].Negative-expected[i].Negative) > 1e-9 ||
+			math.Abs(flows[i].Net-expected[i].Net) > 1e-9 {
+			t.Errorf("alternative %d: expected %+v, got %+v", i, expected[i], flows[i])
+		}
+	}
+}
+
+func TestPrometheeFlowsPreferenceFunctions(t *testing.T) {
+	matrix := [][]float64{{0.5}, {0.3}, {0.2}}
+
+	tests := []struct {
+		function PreferenceFunction
+		net      []float64
+	}{
+		{PreferenceFunction{Type: PreferenceUsual}, []float64{1, 0, -1}},
+		{PreferenceFunction{Type: PreferenceUShape, Q: 0.15}, []float64{1, -0.5, -0.5}},
+		{PreferenceFunction{Type: PreferenceVShape, P: 0.4}, []float64{0.625, -0.125, -0.5}},
+		{PreferenceFunction{Type: PreferenceLinear, Q: 0.05, P: 0.25}, []float64{0.875, -0.25, -0.625}},
+		{PreferenceFunction{Type: PreferenceGaussian, S: 0.1}, []float64{0.926778, -0.235598, -0.69118}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function.Type, func(t *testing.T) {
+			flows, err := PrometheeFlows(matrix, []float64{1}, []string{ImpactBenefit}, []PreferenceFunction{tt.function})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range tt.net {
+				if math.Abs(flows[i].Net-tt.net[i]) > 1e-6 {
+					t.Errorf("alternative %d: expected net flow %v, got %v", i, tt.net[i], flows[i].Net)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package ahp
+
+import (
+	"fmt"
+)
+
+const (
+	TiePolicyDense       = "dense"
+	TiePolicyCompetition = "competition"
+	TiePolicyFractional  = "fractional"
+)
+
+// Ranks ranks the scores from the highest, equal scores share a rank according to the tie policy:
+// dense (1, 1, 2), competition (1, 1, 3) or fractional (1.5, 1.5, 3).
+func Ranks(scores []float64, policy string) ([]float64, error) {
+	if policy == "" {
+		policy = TiePolicyCompetition
+	}
+
+	order := Ranking(scores)
+	ranks := make([]float64, len(scores))
+
+	dense := 0
+	for start := 0; start < len(order); {
+		//MENCARI KELOMPOK SKOR YANG SAMA
+		end := start
+		for end+1 < len(order) && scores[order[end+1]] == scores[order[start]] {
+			end++
+		}
+		dense++
+
+		var rank float64
+		switch policy {
+		case TiePolicyDense:
+			rank = float64(dense)
+		case TiePolicyCompetition:
+			rank = float64(start + 1)
+		case TiePolicyFractional:
+			rank = float64(start+end+2) / 2
+		default:
+			return nil, fmt.Errorf("unknown tie policy %s", policy)
+		}
+
+		for k := start; k <= end; k++ {
+			ranks[order[k]] = rank
+		}
+		start = end + 1
+	}
+
+	return ranks, nil
+}
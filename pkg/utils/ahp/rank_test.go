@@ -0,0 +1,36 @@
+package ahp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRanksTiePolicies(t *testing.T) {
+	scores := []float64{0.3, 0.5, 0.2, 0.5, 0.3, 0.1}
+
+	tests := []struct {
+		policy   string
+		expected []float64
+	}{
+		{TiePolicyDense, []float64{2, 1, 3, 1, 2, 4}},
+		{TiePolicyCompetition, []float64{3, 1, 5, 1, 3, 6}},
+		{TiePolicyFractional, []float64{3.5, 1.5, 5, 1.5, 3.5, 6}},
+		{"", []float64{3, 1, 5, 1, 3, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			ranks, err := Ranks(scores, tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ranks, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, ranks)
+			}
+		})
+	}
+
+	if _, err := Ranks(scores, "olympic"); err == nil {
+		t.Error("expected an unknown tie policy to be rejected")
+	}
+}
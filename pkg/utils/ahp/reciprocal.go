@@ -0,0 +1,238 @@
+package ahp
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	SaatyMin = 1.0 / 9
+	SaatyMax = 9.0
+
+	ReciprocalTolerance = 0.01
+)
+
+type Judgment struct {
+	Row   int     `json:"row" example:"0"`
+	Col   int     `json:"col" example:"1"`
+	Value float64 `json:"value" example:"3"`
+}
+
+type InputError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// SnapSaatyValue replaces rounded inputs such as 0.333 by the exact scale value 1/3.
+func SnapSaatyValue(value float64) float64 {
+	for _, v := range SaatyScale() {
+		if math.Abs(value-v)/v <= ReciprocalTolerance {
+			return v
+		}
+	}
+	return value
+}
+
+func validateSaatyValue(field string, value float64) []InputError {
+	if value < SaatyMin*(1-ReciprocalTolerance) || value > SaatyMax {
+		return []InputError{{Field: field, Message: fmt.Sprintf("value %v must be between 1/9 and 9", value)}}
+	}
+	return nil
+}
+
+// ValidatePairwise checks a full matrix and rebuilds it with exact reciprocals from the upper triangle. A missing
+// judgment is nil on both sides of the diagonal, a cell whose mirror is nil or not its reciprocal is rejected.
+func ValidatePairwise(field string, pairwise [][]*float64) ([][]*float64, []InputError) {
+	errs := make([]InputError, 0)
+	n := len(pairwise)
+	if n == 0 {
+		return nil, []InputError{{Field: field, Message: "matrix must not be empty"}}
+	}
+
+	for i, row := range pairwise {
+		if len(row) != n {
+			errs = append(errs, InputError{Field: fmt.Sprintf("%s[%d]", field, i), Message: fmt.Sprintf("row has %d values but %d are expected", len(row), n)})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	for i := 0; i < n; i++ {
+		if pairwise[i][i] != nil && *pairwise[i][i] != 1 {
+			errs = append(errs, InputError{Field: fmt.Sprintf("%s[%d][%d]", field, i, i), Message: "diagonal value must be 1"})
+		}
+		for j := 0; j < n; j++ {
+			if i == j || pairwise[i][j] == nil {
+				continue
+			}
+			cellField := fmt.Sprintf("%s[%d][%d]", field, i, j)
+			errs = append(errs, validateSaatyValue(cellField, *pairwise[i][j])...)
+
+			switch {
+			case pairwise[j][i] == nil:
+				errs = append(errs, InputError{Field: cellField, Message: fmt.Sprintf("value %v has no reciprocal at [%d][%d], fill both cells or leave both null", *pairwise[i][j], j, i)})
+			case j > i && math.Abs(*pairwise[i][j]**pairwise[j][i]-1) > ReciprocalTolerance:
+				errs = append(errs, InputError{Field: cellField, Message: fmt.Sprintf("value %v is not the reciprocal of %v at [%d][%d]", *pairwise[i][j], *pairwise[j][i], j, i)})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	upper := make([][]*float64, n-1)
+	for i := 0; i < n-1; i++ {
+		upper[i] = make([]*float64, n-1-i)
+		for j := i + 1; j < n; j++ {
+			upper[i][j-i-1] = pairwise[i][j]
+		}
+	}
+
+	return MatrixFromUpperTriangle(field, upper)
+}
+
+// MatrixFromUpperTriangle builds the reciprocal matrix from rows holding the cells right of the diagonal,
+// row i has n-1-i values and the trailing empty row may be omitted.
+func MatrixFromUpperTriangle(field string, upper [][]*float64) ([][]*float64, []InputError) {
+	if len(upper) > 0 && len(upper[len(upper)-1]) == 0 {
+		upper = upper[:len(upper)-1]
+	}
+	if len(upper) == 0 {
+		return nil, []InputError{{Field: field, Message: "upper triangle must have at least one row"}}
+	}
+
+	errs := make([]InputError, 0)
+	n := len(upper) + 1
+	for i, row := range upper {
+		if len(row) != n-1-i {
+			errs = append(errs, InputError{Field: fmt.Sprintf("%s[%d]", field, i), Message: fmt.Sprintf("row has %d values but %d are expected", len(row), n-1-i)})
+			continue
+		}
+		for k, value := range row {
+			if value != nil {
+				errs = append(errs, validateSaatyValue(fmt.Sprintf("%s[%d][%d]", field, i, k), *value)...)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	pairwise := newIncomplete(n)
+	for i, row := range upper {
+		for k, value := range row {
+			if value != nil {
+				setJudgment(pairwise, i, i+k+1, *value)
+			}
+		}
+	}
+
+	return pairwise, nil
+}
+
+// MatrixFromJudgments builds the reciprocal n×n matrix from (row, col, value) judgments, pairs that are not
+// judged stay missing.
+func MatrixFromJudgments(field string, n int, judgments []Judgment) ([][]*float64, []InputError) {
+	return judgmentMatrix(field, n, judgments, true)
+}
+
+func judgmentMatrix(field string, n int, judgments []Judgment, checkRange bool) ([][]*float64, []InputError) {
+	errs := make([]InputError, 0)
+	pairwise := newIncomplete(n)
+
+	for k, judgment := range judgments {
+		judgmentField := fmt.Sprintf("%s[%d]", field, k)
+		switch {
+		case judgment.Row < 0 || judgment.Row >= n:
+			errs = append(errs, InputError{Field: judgmentField + ".row", Message: fmt.Sprintf("row must be between 0 and %d", n-1)})
+		case judgment.Col < 0 || judgment.Col >= n:
+			errs = append(errs, InputError{Field: judgmentField + ".col", Message: fmt.Sprintf("col must be between 0 and %d", n-1)})
+		case judgment.Row == judgment.Col:
+			errs = append(errs, InputError{Field: judgmentField, Message: "a criteria cannot be compared with itself"})
+		case pairwise[judgment.Row][judgment.Col] != nil:
+			errs = append(errs, InputError{Field: judgmentField, Message: fmt.Sprintf("pair (%d, %d) is judged more than once", judgment.Row, judgment.Col)})
+		default:
+			if checkRange {
+				valueErrs := validateSaatyValue(judgmentField+".value", judgment.Value)
+				if len(valueErrs) > 0 {
+					errs = append(errs, valueErrs...)
+					continue
+				}
+			}
+			setJudgment(pairwise, judgment.Row, judgment.Col, judgment.Value)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return pairwise, nil
+}
+
+func newIncomplete(n int) [][]*float64 {
+	pairwise := make([][]*float64, n)
+	for i := range pairwise {
+		pairwise[i] = make([]*float64, n)
+		one := 1.0
+		pairwise[i][i] = &one
+	}
+	return pairwise
+}
+
+func setJudgment(pairwise [][]*float64, i int, j int, value float64) {
+	value = SnapSaatyValue(value)
+	reciprocal := 1 / value
+	pairwise[i][j] = &value
+	pairwise[j][i] = &reciprocal
+}
+
+// ValidateRatioMatrix checks a complete positive reciprocal matrix and rebuilds it with exact reciprocals from
+// the upper triangle. Unlike ValidatePairwise the values are not limited to the 1/9..9 scale, a matrix derived
+// from weights such as RatioMatrix can exceed it.
+func ValidateRatioMatrix(field string, pairwise [][]float64) ([][]float64, []InputError) {
+	n := len(pairwise)
+	if n == 0 {
+		return nil, []InputError{{Field: field, Message: "matrix must not be empty"}}
+	}
+
+	errs := make([]InputError, 0)
+	for i, row := range pairwise {
+		if len(row) != n {
+			errs = append(errs, InputError{Field: fmt.Sprintf("%s[%d]", field, i), Message: fmt.Sprintf("row has %d values but %d are expected", len(row), n)})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			cellField := fmt.Sprintf("%s[%d][%d]", field, i, j)
+			switch {
+			case i == j && pairwise[i][j] != 1:
+				errs = append(errs, InputError{Field: cellField, Message: "diagonal value must be 1"})
+			case pairwise[i][j] <= 0 || math.IsInf(pairwise[i][j], 0) || math.IsNaN(pairwise[i][j]):
+				errs = append(errs, InputError{Field: cellField, Message: fmt.Sprintf("value %v must be positive", pairwise[i][j])})
+			case j > i && pairwise[j][i] > 0 && math.Abs(pairwise[i][j]*pairwise[j][i]-1) > ReciprocalTolerance:
+				errs = append(errs, InputError{Field: cellField, Message: fmt.Sprintf("value %v is not the reciprocal of %v at [%d][%d]", pairwise[i][j], pairwise[j][i], j, i)})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	validated := make([][]float64, n)
+	for i := range validated {
+		validated[i] = make([]float64, n)
+		validated[i][i] = 1
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			validated[i][j] = pairwise[i][j]
+			validated[j][i] = 1 / pairwise[i][j]
+		}
+	}
+	return validated, nil
+}
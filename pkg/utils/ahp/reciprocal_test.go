@@ -0,0 +1,47 @@
+package ahp
+
+import "testing"
+
+func cell(v float64) *float64 {
+	return &v
+}
+
+func TestValidatePairwiseRejectsMissingReciprocal(t *testing.T) {
+	pairwise := [][]*float64{
+		{cell(1), cell(3), nil},
+		{nil, cell(1), cell(5)},
+		{nil, cell(1.0 / 5), cell(1)},
+	}
+	if _, errs := ValidatePairwise("pairwise", pairwise); len(errs) != 1 || errs[0].Field != "pairwise[0][1]" {
+		t.Errorf("expected one error on pairwise[0][1], got %v", errs)
+	}
+
+	pairwise[1][0] = cell(1.0 / 2)
+	if _, errs := ValidatePairwise("pairwise", pairwise); len(errs) != 1 || errs[0].Field != "pairwise[0][1]" {
+		t.Errorf("expected the non reciprocal pairwise[0][1], got %v", errs)
+	}
+
+	pairwise[1][0] = cell(0.333)
+	validated, errs := ValidatePairwise("pairwise", pairwise)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if validated[0][2] != nil || validated[2][0] != nil || *validated[1][0] != 1.0/3 {
+		t.Errorf("expected exact reciprocals and a missing [0][2], got %v %v %v", validated[0][2], validated[2][0], *validated[1][0])
+	}
+}
+
+func TestValidateRatioMatrix(t *testing.T) {
+	for code, template := range DefaultSubCriteria() {
+		if _, errs := ValidateRatioMatrix("pairwise", template.Pairwise); len(errs) > 0 {
+			t.Errorf("%s: %v", code, errs)
+		}
+	}
+
+	if _, errs := ValidateRatioMatrix("pairwise", [][]float64{{1, 3}, {3, 1}}); len(errs) != 1 {
+		t.Errorf("expected a reciprocal error, got %v", errs)
+	}
+	if _, errs := ValidateRatioMatrix("pairwise", [][]float64{{1, 0}, {0, 1}}); len(errs) != 2 {
+		t.Errorf("expected two positivity errors, got %v", errs)
+	}
+}
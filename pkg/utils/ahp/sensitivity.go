@@ -0,0 +1,117 @@
+package ahp
+
+import (
+	"sort"
+)
+
+type RankReversal struct {
+	Weight float64 `json:"weight"`
+	From   int     `json:"from"`
+	To     int     `json:"to"`
+	Rank   int     `json:"rank"`
+}
+
+// RescaleWeights sets weights[index] to value and rescales the others proportionally so they still sum to 1.
+func RescaleWeights(weights []float64, index int, value float64) []float64 {
+	rescaled := make([]float64, len(weights))
+	rest := 1 - weights[index]
+
+	for j := range weights {
+		switch {
+		case j == index:
+			rescaled[j] = value
+		case rest <= 0:
+			rescaled[j] = (1 - value) / float64(len(weights)-1)
+		default:
+			rescaled[j] = weights[j] * (1 - value) / rest
+		}
+	}
+
+	return rescaled
+}
+
+func Scores(matrix [][]float64, weights []float64) []float64 {
+	scores := make([]float64, len(matrix))
+	for i, row := range matrix {
+		for j, value := range row {
+			scores[i] += value * weights[j]
+		}
+	}
+	return scores
+}
+
+// Ranking returns the alternative indexes ordered from the highest score.
+func Ranking(scores []float64) []int {
+	ranking := make([]int, len(scores))
+	for i := range ranking {
+		ranking[i] = i
+	}
+	sort.SliceStable(ranking, func(a, b int) bool {
+		return scores[ranking[a]] > scores[ranking[b]]
+	})
+	return ranking
+}
+
+// RankReversalThresholds returns every weight of criteria index in the open interval (minWeight, maxWeight) at which two
+// alternatives swap places, ordered by weight. Under proportional rescaling every score is linear in that weight,
+// so the thresholds are line crossings. From is the alternative ahead just below the weight and Rank its 1-based
+// rank there, Rank 1 means the top alternative changes.
+func RankReversalThresholds(matrix [][]float64, weights []float64, index int, minWeight float64, maxWeight float64) []RankReversal {
+	thresholds := make([]RankReversal, 0)
+	if len(matrix) < 2 || minWeight >= maxWeight {
+		return thresholds
+	}
+
+	//SKOR SETIAP ALTERNATIF PADA BOBOT 0 DAN 1
+	low := Scores(matrix, RescaleWeights(weights, index, 0))
+	high := Scores(matrix, RescaleWeights(weights, index, 1))
+
+	scoresAt := func(v float64) []float64 {
+		scores := make([]float64, len(matrix))
+		for i := range scores {
+			scores[i] = low[i] + (high[i]-low[i])*v
+		}
+		return scores
+	}
+
+	//TITIK POTONG SETIAP PASANG ALTERNATIF DI DALAM BATAS
+	const epsilon = 1e-9
+	for a := 0; a < len(matrix); a++ {
+		for b := a + 1; b < len(matrix); b++ {
+			diffLow := low[a] - low[b]
+			diffHigh := high[a] - high[b]
+			if diffLow == diffHigh {
+				continue
+			}
+			v := diffLow / (diffLow - diffHigh)
+			if v <= minWeight || v >= maxWeight {
+				continue
+			}
+
+			before := scoresAt(v - epsilon)
+			from, to := a, b
+			if before[a] < before[b] {
+				from, to = b, a
+			}
+
+			rank := 0
+			for k, i := range Ranking(before) {
+				if i == from || i == to {
+					rank = k + 1
+					break
+				}
+			}
+
+			thresholds = append(thresholds, RankReversal{Weight: v, From: from, To: to, Rank: rank})
+		}
+	}
+
+	sort.SliceStable(thresholds, func(i, j int) bool {
+		if thresholds[i].Weight != thresholds[j].Weight {
+			return thresholds[i].Weight < thresholds[j].Weight
+		}
+		return thresholds[i].Rank < thresholds[j].Rank
+	})
+
+	return thresholds
+}
@@ -0,0 +1,44 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+// Three alternatives on two criteria, with weight v on the first criteria the scores are
+// A = 0.2 + 0.6v, B = 0.5 and C = 0.6 - 0.4v. They cross at v = 0.25 (B, C), 0.4 (A, C) and 0.5 (A, B).
+var sensitivityMatrix = [][]float64{
+	{0.8, 0.2},
+	{0.5, 0.5},
+	{0.2, 0.6},
+}
+
+func TestRankReversalThresholdsReturnsEveryCrossing(t *testing.T) {
+	thresholds := RankReversalThresholds(sensitivityMatrix, []float64{0.5, 0.5}, 0, 0, 1)
+
+	expected := []RankReversal{
+		{Weight: 0.25, From: 2, To: 1, Rank: 1},
+		{Weight: 0.4, From: 2, To: 0, Rank: 2},
+		{Weight: 0.5, From: 1, To: 0, Rank: 1},
+	}
+	if len(thresholds) != len(expected) {
+		t.Fatalf("expected %d thresholds, got %v", len(expected), thresholds)
+	}
+	for i, e := range expected {
+		got := thresholds[i]
+		if math.Abs(got.Weight-e.Weight) > 1e-9 || got.From != e.From || got.To != e.To || got.Rank != e.Rank {
+			t.Errorf("threshold %d: expected %+v, got %+v", i, e, got)
+		}
+	}
+}
+
+func TestRankReversalThresholdsAppliesBounds(t *testing.T) {
+	thresholds := RankReversalThresholds(sensitivityMatrix, []float64{0.5, 0.5}, 0, 0.2, 0.3)
+	if len(thresholds) != 1 || math.Abs(thresholds[0].Weight-0.25) > 1e-9 {
+		t.Errorf("expected only the crossing at 0.25, got %v", thresholds)
+	}
+
+	if thresholds = RankReversalThresholds(sensitivityMatrix, []float64{0.5, 0.5}, 0, 0.6, 1); len(thresholds) != 0 {
+		t.Errorf("expected no crossing above 0.6, got %v", thresholds)
+	}
+}
@@ -0,0 +1,199 @@
+package ahp
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+const (
+	SamplingDirichlet    = "dirichlet"
+	SamplingPerturbation = "perturbation"
+
+	SMAAMaxWorkers = 8
+)
+
+// WeightSampler draws one criteria weight vector, it must only use rng for randomness.
+type WeightSampler func(rng *rand.Rand) ([]float64, error)
+
+// ScoreFunc scores every alternative for the given criteria weights.
+type ScoreFunc func(weights []float64) ([]float64, error)
+
+type SMAAResult struct {
+	// Acceptability[i][r] is the share of samples where alternative i is ranked r+1.
+	Acceptability [][]float64
+	// CentralWeights[i] is the mean weight vector of the samples where alternative i is ranked first, nil when it never is.
+	CentralWeights [][]float64
+}
+
+type smaaSample struct {
+	weights []float64
+	ranking []int
+	err     error
+}
+
+// SMAA runs the samples in a pool of at most workers goroutines. Sample k draws from its own source seeded
+// with seed+k and the results are aggregated in sample order, so the outcome only depends on the seed. The
+// pool stops and ctx.Err() is returned once ctx is done.
+func SMAA(ctx context.Context, alternatives int, samples int, workers int, seed int64, sampler WeightSampler, score ScoreFunc) (*SMAAResult, error) {
+	if workers < 1 || workers > SMAAMaxWorkers {
+		workers = SMAAMaxWorkers
+	}
+
+	results := make([]smaaSample, samples)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				rng := rand.New(rand.NewSource(seed + int64(k)))
+				weights, err := sampler(rng)
+				if err != nil {
+					results[k].err = err
+					continue
+				}
+				scores, err := score(weights)
+				if err != nil {
+					results[k].err = err
+					continue
+				}
+				results[k] = smaaSample{weights: weights, ranking: Ranking(scores)}
+			}
+		}()
+	}
+feed:
+	for k := 0; k < samples; k++ {
+		select {
+		case jobs <- k:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &SMAAResult{
+		Acceptability:  make([][]float64, alternatives),
+		CentralWeights: make([][]float64, alternatives),
+	}
+	for i := range result.Acceptability {
+		result.Acceptability[i] = make([]float64, alternatives)
+	}
+
+	//AGREGASI BERURUTAN AGAR HASIL SAMA UNTUK SEED YANG SAMA
+	firsts := make([]int, alternatives)
+	for _, sample := range results {
+		if sample.err != nil {
+			return nil, sample.err
+		}
+		for r, i := range sample.ranking {
+			result.Acceptability[i][r]++
+		}
+
+		first := sample.ranking[0]
+		if result.CentralWeights[first] == nil {
+			result.CentralWeights[first] = make([]float64, len(sample.weights))
+		}
+		for j, w := range sample.weights {
+			result.CentralWeights[first][j] += w
+		}
+		firsts[first]++
+	}
+
+	for i := range result.Acceptability {
+		for r := range result.Acceptability[i] {
+			result.Acceptability[i][r] /= float64(samples)
+		}
+		for j := range result.CentralWeights[i] {
+			result.CentralWeights[i][j] /= float64(firsts[i])
+		}
+	}
+
+	return result, nil
+}
+
+// DirichletSampler draws weights from Dirichlet(concentration * weights), the higher the concentration the
+// closer the samples stay to weights.
+func DirichletSampler(weights []float64, concentration float64) WeightSampler {
+	return func(rng *rand.Rand) ([]float64, error) {
+		sample := make([]float64, len(weights))
+		sum := 0.0
+		for j, w := range weights {
+			sample[j] = gammaSample(rng, math.Max(concentration*w, 1e-3))
+			sum += sample[j]
+		}
+		for j := range sample {
+			sample[j] /= sum
+		}
+		return sample, nil
+	}
+}
+
+// PerturbMatrix multiplies every upper triangle judgment by a log-uniform factor in [e^-spread, e^spread],
+// keeps it within 1/9..9 and restores the reciprocals.
+func PerturbMatrix(rng *rand.Rand, pairwise [][]float64, spread float64) [][]float64 {
+	perturbed := copyMatrix(pairwise)
+	for i := range pairwise {
+		for j := i + 1; j < len(pairwise); j++ {
+			value := pairwise[i][j] * math.Exp(spread*(2*rng.Float64()-1))
+			value = math.Min(SaatyMax, math.Max(SaatyMin, value))
+			perturbed[i][j] = value
+			perturbed[j][i] = 1 / value
+		}
+	}
+	return perturbed
+}
+
+// PerturbFuzzyMatrix multiplies every upper triangle fuzzy judgment by one log-uniform factor in
+// [e^-spread, e^spread], so l <= m <= u is kept, clamps it to 1/9..9 and restores the reciprocals.
+func PerturbFuzzyMatrix(rng *rand.Rand, pairwise [][]TFN, spread float64) [][]TFN {
+	perturbed := make([][]TFN, len(pairwise))
+	for i := range pairwise {
+		perturbed[i] = append([]TFN{}, pairwise[i]...)
+	}
+
+	for i := range pairwise {
+		for j := i + 1; j < len(pairwise); j++ {
+			factor := math.Exp(spread * (2*rng.Float64() - 1))
+			var value TFN
+			for k := range value {
+				value[k] = math.Min(SaatyMax, math.Max(SaatyMin, pairwise[i][j][k]*factor))
+			}
+			perturbed[i][j] = value
+			perturbed[j][i] = value.Inverse()
+		}
+	}
+	return perturbed
+}
+
+// gammaSample draws from Gamma(shape, 1) with the method of Marsaglia and Tsang.
+func gammaSample(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return gammaSample(rng, shape+1) * math.Pow(rng.Float64(), 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rng.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
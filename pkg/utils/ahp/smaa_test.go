@@ -0,0 +1,58 @@
+package ahp
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestSMAAStopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sampler := DirichletSampler([]float64{0.5, 0.5}, 100)
+	score := func(weights []float64) ([]float64, error) {
+		return Scores([][]float64{{1, 0}, {0, 1}}, weights), nil
+	}
+	if _, err := SMAA(ctx, 2, 1000, 4, 0, sampler, score); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	first, err := SMAA(context.Background(), 2, 200, 4, 0, sampler, score)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := SMAA(context.Background(), 2, 200, 2, 0, sampler, score)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range first.Acceptability {
+		for r := range first.Acceptability[i] {
+			if first.Acceptability[i][r] != second.Acceptability[i][r] {
+				t.Fatalf("seed 0 is not reproducible: %v and %v", first.Acceptability, second.Acceptability)
+			}
+		}
+	}
+}
+
+func TestPerturbFuzzyMatrix(t *testing.T) {
+	pairwise := [][]TFN{
+		{{1, 1, 1}, {2, 3, 4}, {8, 9, 9}},
+		{TFN{2, 3, 4}.Inverse(), {1, 1, 1}, {1, 2, 3}},
+		{TFN{8, 9, 9}.Inverse(), TFN{1, 2, 3}.Inverse(), {1, 1, 1}},
+	}
+
+	perturbed := PerturbFuzzyMatrix(rand.New(rand.NewSource(1)), pairwise, 0.5)
+	for i := range perturbed {
+		for j := range perturbed[i] {
+			value := perturbed[i][j]
+			if value[0] > value[1] || value[1] > value[2] || value[0] < SaatyMin || value[2] > SaatyMax {
+				t.Errorf("[%d][%d] = %v is not a TFN within 1/9..9", i, j, value)
+			}
+			if j > i && perturbed[j][i] != value.Inverse() {
+				t.Errorf("[%d][%d] = %v is not the reciprocal of %v", j, i, perturbed[j][i], value)
+			}
+		}
+	}
+}
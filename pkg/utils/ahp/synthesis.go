@@ -0,0 +1,88 @@
+package ahp
+
+import (
+	"fmt"
+)
+
+const (
+	SynthesisDistributive = "distributive"
+	SynthesisIdeal        = "ideal"
+)
+
+// Synthesize returns the local priorities of the alternatives (rows) per criteria (columns) for the given mode.
+// Distributive keeps them as they are, ideal divides every column by its ideal. ideals holds the ideal of
+// every column in absolute measurement, the best sub criteria intensity of a rated criteria, so removing any
+// alternative leaves the others untouched. Without ideals (relative measurement) a column is divided by its
+// best alternative, which only protects the order against removing a non-dominant alternative. TOPSIS
+// normalizes the decision matrix itself, so its local priorities are kept as they are too.
+func Synthesize(matrix [][]float64, synthesis string, ideals []float64) ([][]float64, error) {
+	synthesized := copyMatrix(matrix)
+
+	switch synthesis {
+	case "", SynthesisDistributive, SynthesisTopsis:
+		return synthesized, nil
+	case SynthesisIdeal:
+	default:
+		return nil, fmt.Errorf("unknown synthesis %s", synthesis)
+	}
+
+	if len(matrix) == 0 {
+		return synthesized, nil
+	}
+
+	if ideals != nil && len(ideals) != len(matrix[0]) {
+		return nil, fmt.Errorf("%d ideals for %d criteria", len(ideals), len(matrix[0]))
+	}
+
+	//MEMBAGI SETIAP KOLOM DENGAN IDEALNYA ATAU ALTERNATIF TERBAIK
+	for j := range matrix[0] {
+		best := 0.0
+		for i := range matrix {
+			if matrix[i][j] > best {
+				best = matrix[i][j]
+			}
+		}
+		if ideals != nil {
+			best = ideals[j]
+		}
+		for i := range matrix {
+			if best > 0 {
+				synthesized[i][j] = matrix[i][j] / best
+			}
+		}
+	}
+
+	return synthesized, nil
+}
+
+// OrderPreserved reports whether the alternatives left after removing one keep their relative order, reduced
+// holds the scores without the removed alternative.
+func OrderPreserved(scores []float64, reduced []float64, removed int) bool {
+	const epsilon = 1e-9
+
+	index := make([]int, 0)
+	for i := range scores {
+		if i != removed {
+			index = append(index, i)
+		}
+	}
+
+	for a := 0; a < len(index); a++ {
+		for b := a + 1; b < len(index); b++ {
+			if compare(scores[index[a]], scores[index[b]], epsilon) != compare(reduced[a], reduced[b], epsilon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func compare(a float64, b float64, epsilon float64) int {
+	switch {
+	case a-b > epsilon:
+		return 1
+	case b-a > epsilon:
+		return -1
+	}
+	return 0
+}
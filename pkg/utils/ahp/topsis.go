@@ -0,0 +1,91 @@
+package ahp
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	SynthesisTopsis = "topsis"
+
+	ImpactBenefit = "benefit"
+	ImpactCost    = "cost"
+)
+
+type TopsisResult struct {
+	DistancePositive float64 `json:"distance_positive"`
+	DistanceNegative float64 `json:"distance_negative"`
+	Closeness        float64 `json:"closeness"`
+}
+
+// Topsis ranks the alternatives (rows) by their closeness D-/(D+ + D-) to the positive ideal after vector
+// normalization and weighting of every criteria (columns). A benefit criteria prefers the highest value, a
+// cost criteria the lowest. When every alternative is identical the closeness is 0.5.
+func Topsis(matrix [][]float64, weights []float64, impacts []string) ([]TopsisResult, error) {
+	if len(matrix) == 0 {
+		return []TopsisResult{}, nil
+	}
+	m := len(weights)
+	if len(impacts) != m {
+		return nil, fmt.Errorf("%d impacts given for %d criteria", len(impacts), m)
+	}
+
+	//NORMALISASI VEKTOR DAN PEMBOBOTAN
+	weighted := make([][]float64, len(matrix))
+	for i := range matrix {
+		if len(matrix[i]) != m {
+			return nil, fmt.Errorf("alternative %d has %d values but %d criteria are weighted", i, len(matrix[i]), m)
+		}
+		weighted[i] = make([]float64, m)
+	}
+	for j := 0; j < m; j++ {
+		norm := 0.0
+		for i := range matrix {
+			norm += matrix[i][j] * matrix[i][j]
+		}
+		norm = math.Sqrt(norm)
+		for i := range matrix {
+			if norm > 0 {
+				weighted[i][j] = weights[j] * matrix[i][j] / norm
+			}
+		}
+	}
+
+	//SOLUSI IDEAL POSITIF DAN NEGATIF
+	positive := make([]float64, m)
+	negative := make([]float64, m)
+	for j := 0; j < m; j++ {
+		high, low := weighted[0][j], weighted[0][j]
+		for i := range weighted {
+			high = math.Max(high, weighted[i][j])
+			low = math.Min(low, weighted[i][j])
+		}
+
+		switch impacts[j] {
+		case "", ImpactBenefit:
+			positive[j], negative[j] = high, low
+		case ImpactCost:
+			positive[j], negative[j] = low, high
+		default:
+			return nil, fmt.Errorf("unknown impact %s", impacts[j])
+		}
+	}
+
+	results := make([]TopsisResult, len(weighted))
+	for i, row := range weighted {
+		for j, v := range row {
+			results[i].DistancePositive += (v - positive[j]) * (v - positive[j])
+			results[i].DistanceNegative += (v - negative[j]) * (v - negative[j])
+		}
+		results[i].DistancePositive = math.Sqrt(results[i].DistancePositive)
+		results[i].DistanceNegative = math.Sqrt(results[i].DistanceNegative)
+
+		total := results[i].DistancePositive + results[i].DistanceNegative
+		results[i].Closeness = 0.5
+		if total > 0 {
+			results[i].Closeness = results[i].DistanceNegative / total
+		}
+	}
+
+	return results, nil
+}
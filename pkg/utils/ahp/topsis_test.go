@@ -0,0 +1,64 @@
+package ahp
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// fighterExample is the fighter aircraft example of Hwang and Yoon (1981): maximum speed, ferry range, maximum
+// payload, acquisition cost, reliability and maneuverability, the cost is the only cost criteria. The published
+// closeness is 0.643, 0.268, 0.613 and 0.312.
+func fighterExample() ([][]float64, []float64, []string) {
+	matrix := [][]float64{
+		{2.0, 1500, 20000, 5.5, 5, 9},
+		{2.5, 2700, 18000, 6.5, 3, 5},
+		{1.8, 2000, 21000, 4.5, 7, 7},
+		{2.2, 1800, 20000, 5.0, 5, 5},
+	}
+	weights := []float64{0.2, 0.1, 0.1, 0.1, 0.2, 0.3}
+	impacts := []string{ImpactBenefit, ImpactBenefit, ImpactBenefit, ImpactCost, ImpactBenefit, ImpactBenefit}
+	return matrix, weights, impacts
+}
+
+func TestTopsisReferenceValues(t *testing.T) {
+	matrix, weights, impacts := fighterExample()
+
+	results, err := Topsis(matrix, weights, impacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []TopsisResult{
+		{DistancePositive: 0.054557, DistanceNegative: 0.098383, Closeness: 0.643277},
+		{DistancePositive: 0.119679, DistanceNegative: 0.043903, Closeness: 0.268384},
+		{DistancePositive: 0.057977, DistanceNegative: 0.092044, Closeness: 0.613542},
+		{DistancePositive: 0.100937, DistanceNegative: 0.045836, Closeness: 0.312291},
+	}
+	closeness := make([]float64, len(results))
+	for i, result := range results {
+		closeness[i] = result.Closeness
+		if math.Abs(result.DistancePositive-expected[i].DistancePositive) > 1e-6 ||
+			math.Abs(result.DistanceNegative-expected[i].DistanceNegative) > 1e-6 ||
+			math.Abs(result.Closeness-expected[i].Closeness) > 1e-6 {
+			t.Errorf("alternative %d: expected %+v, got %+v", i, expected[i], result)
+		}
+	}
+
+	//A1 > A3 > A4 > A2 SEPERTI PADA CONTOH ASLINYA
+	if order := Ranking(closeness); !reflect.DeepEqual(order, []int{0, 2, 3, 1}) {
+		t.Errorf("expected the order A1, A3, A4, A2, got %v", order)
+	}
+}
+
+func TestTopsisIdenticalAlternatives(t *testing.T) {
+	results, err := Topsis([][]float64{{0.4, 0.6}, {0.4, 0.6}}, []float64{0.5, 0.5}, []string{ImpactBenefit, ImpactCost})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range results {
+		if result.Closeness != 0.5 {
+			t.Errorf("alternative %d: expected closeness 0.5, got %v", i, result.Closeness)
+		}
+	}
+}
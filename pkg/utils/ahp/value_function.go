@@ -0,0 +1,112 @@
+package ahp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	ValueLinear          = "linear"
+	ValuePiecewiseLinear = "piecewise_linear"
+	ValueExponential     = "exponential"
+	ValueStep            = "step"
+)
+
+type ValuePoint struct {
+	X float64 `json:"x" example:"300"`
+	Y float64 `json:"y" example:"0.6"`
+}
+
+// ValueFunction maps a raw measurement onto a 0..1 score. Linear and exponential run from Min to Max, reversed
+// when Decreasing, exponential bends by Rho (Kirkwood). Piecewise linear interpolates the points, step takes the
+// y of the last point at or below x.
+type ValueFunction struct {
+	Type       string
+	Min        float64
+	Max        float64
+	Decreasing bool
+	Rho        float64
+	Points     []ValuePoint
+}
+
+func (f ValueFunction) Validate() error {
+	switch f.Type {
+	case ValueLinear, ValueExponential:
+		if f.Max <= f.Min {
+			return fmt.Errorf("%s value function needs min < max", f.Type)
+		}
+	case ValuePiecewiseLinear, ValueStep:
+		required := 1
+		if f.Type == ValuePiecewiseLinear {
+			required = 2
+		}
+		if len(f.Points) < required {
+			return fmt.Errorf("%s value function needs at least %d points", f.Type, required)
+		}
+		for k, point := range f.Points {
+			if point.Y < 0 || point.Y > 1 {
+				return fmt.Errorf("points[%d].y %v must be between 0 and 1", k, point.Y)
+			}
+			if k > 0 && point.X <= f.Points[k-1].X {
+				return fmt.Errorf("points[%d].x must be greater than points[%d].x", k, k-1)
+			}
+		}
+	default:
+		return errors.New("unknown value function type " + f.Type)
+	}
+	return nil
+}
+
+func (f ValueFunction) Value(x float64) float64 {
+	var v float64
+	switch f.Type {
+	case ValueLinear, ValueExponential:
+		d := x - f.Min
+		if f.Decreasing {
+			d = f.Max - x
+		}
+		d = math.Min(f.Max-f.Min, math.Max(0, d))
+
+		//RHO 0 BERARTI FUNGSI LINEAR
+		if f.Type == ValueLinear || f.Rho == 0 {
+			v = d / (f.Max - f.Min)
+		} else {
+			v = (1 - math.Exp(-d/f.Rho)) / (1 - math.Exp(-(f.Max-f.Min)/f.Rho))
+		}
+	case ValuePiecewiseLinear:
+		last := len(f.Points) - 1
+		switch {
+		case x <= f.Points[0].X:
+			v = f.Points[0].Y
+		case x >= f.Points[last].X:
+			v = f.Points[last].Y
+		default:
+			for k := 1; k <= last; k++ {
+				if x <= f.Points[k].X {
+					a, b := f.Points[k-1], f.Points[k]
+					v = a.Y + (x-a.X)/(b.X-a.X)*(b.Y-a.Y)
+					break
+				}
+			}
+		}
+	case ValueStep:
+		for _, point := range f.Points {
+			if x >= point.X {
+				v = point.Y
+			}
+		}
+	}
+
+	return math.Min(1, math.Max(0, v))
+}
+
+// RatingValue puts the 0..1 score on the rating scale of a criteria whose best sub criteria intensity is best,
+// a score of 1 then weighs as much as the best rating of a rated column. A criteria without sub criteria keeps
+// the 0..1 scale.
+func (f ValueFunction) RatingValue(x float64, best float64) float64 {
+	if best <= 0 {
+		return f.Value(x)
+	}
+	return f.Value(x) * best
+}
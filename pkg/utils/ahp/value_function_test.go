@@ -0,0 +1,43 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatingValueMixedColumns(t *testing.T) {
+	//KOLOM 0 DINILAI SUB KRITERIA (0.6, 0.3, 0.1), KOLOM 1 FUNGSI NILAI 0..100
+	ratings := []float64{0.6, 0.3, 0.1}
+	best := ratings[0]
+	function := ValueFunction{Type: ValueLinear, Min: 0, Max: 100}
+
+	matrix := [][]float64{
+		{ratings[0], function.RatingValue(0, best)},
+		{ratings[2], function.RatingValue(100, best)},
+		{ratings[1], function.RatingValue(50, best)},
+	}
+
+	//DENGAN BOBOT SAMA, NILAI TERBAIK BERKONTRIBUSI SAMA APAPUN JENIS KOLOMNYA
+	weights := []float64{0.5, 0.5}
+	scores := make([]float64, len(matrix))
+	for i := range matrix {
+		for j := range weights {
+			scores[i] += matrix[i][j] * weights[j]
+		}
+	}
+	if math.Abs(scores[0]-0.3) > 1e-9 || math.Abs(scores[1]-0.35) > 1e-9 || math.Abs(scores[2]-0.3) > 1e-9 {
+		t.Errorf("expected scores (0.3, 0.35, 0.3), got %v", scores)
+	}
+
+	ideal, err := Synthesize(matrix, SynthesisIdeal, []float64{best, best})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ideal[0][0] != 1 || ideal[1][1] != 1 || math.Abs(ideal[2][1]-0.5) > 1e-9 {
+		t.Errorf("expected both columns idealized to 0..1, got %v", ideal)
+	}
+
+	if value := function.RatingValue(50, 0); value != 0.5 {
+		t.Errorf("a criteria without sub criteria keeps the 0..1 scale, got %v", value)
+	}
+}
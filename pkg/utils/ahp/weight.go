@@ -0,0 +1,143 @@
+package ahp
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	MethodAverage       = "average"
+	MethodEigenvector   = "eigenvector"
+	MethodGeometricMean = "geometric_mean"
+)
+
+const (
+	EigenvectorTolerance    = 1e-9
+	EigenvectorMaxIteration = 1000
+)
+
+// WeightMethods are the methods accepted by Weights, requests validate against them with the weight_method tag.
+var WeightMethods = []string{MethodAverage, MethodEigenvector, MethodGeometricMean, MethodFuzzyExtent, MethodFuzzyGeometricMean}
+
+func IsWeightMethod(method string) bool {
+	for _, m := range WeightMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func Weights(pairwise [][]float64, method string) (weights []float64, iterations int, err error) {
+	switch method {
+	case "", MethodAverage:
+		_, weights = AverageWeights(pairwise)
+		return weights, 0, nil
+	case MethodEigenvector:
+		weights, iterations = EigenvectorWeights(pairwise, EigenvectorTolerance, EigenvectorMaxIteration)
+		return weights, iterations, nil
+	case MethodGeometricMean:
+		return GeometricMeanWeights(pairwise), 0, nil
+	case MethodFuzzyExtent, MethodFuzzyGeometricMean:
+		_, weights, err = FuzzyWeights(FuzzifyMatrix(pairwise), method)
+		return weights, 0, err
+	}
+	return nil, 0, fmt.Errorf("unknown weighting method %s", method)
+}
+
+func NormalizeColumns(pairwise [][]float64) [][]float64 {
+	n := len(pairwise)
+	normalized := make([][]float64, n)
+
+	//MENCARI SUM DARI MASING MASING COL
+	colSum := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			colSum[i] += pairwise[j][i]
+		}
+	}
+
+	//NORMALISASI MATRIKS PAIRWISE
+	for i := 0; i < n; i++ {
+		normalized[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			normalized[i][j] = pairwise[i][j] / colSum[j]
+		}
+	}
+
+	return normalized
+}
+
+func AverageWeights(pairwise [][]float64) ([][]float64, []float64) {
+	n := len(pairwise)
+	normalized := NormalizeColumns(pairwise)
+
+	//MENCARI JUMLAH NILAI BARIS & MENCARI RATA RATA (BOBOT KRITERIA)
+	weights := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			sum += normalized[i][j]
+		}
+		weights[i] = sum / float64(n)
+	}
+
+	return normalized, weights
+}
+
+func EigenvectorWeights(pairwise [][]float64, tolerance float64, maxIteration int) ([]float64, int) {
+	n := len(pairwise)
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1 / float64(n)
+	}
+
+	//POWER ITERATION SAMPAI SELISIH BOBOT < TOLERANSI
+	iteration := 0
+	for iteration < maxIteration {
+		iteration++
+
+		next := make([]float64, n)
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				next[i] += pairwise[i][j] * weights[j]
+			}
+			sum += next[i]
+		}
+
+		diff := 0.0
+		for i := 0; i < n; i++ {
+			next[i] /= sum
+			diff = math.Max(diff, math.Abs(next[i]-weights[i]))
+		}
+
+		weights = next
+		if diff < tolerance {
+			break
+		}
+	}
+
+	return weights, iteration
+}
+
+func GeometricMeanWeights(pairwise [][]float64) []float64 {
+	n := len(pairwise)
+	weights := make([]float64, n)
+
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		product := 1.0
+		for j := 0; j < n; j++ {
+			product *= pairwise[i][j]
+		}
+		weights[i] = math.Pow(product, 1/float64(n))
+		sum += weights[i]
+	}
+
+	for i := range weights {
+		weights[i] /= sum
+	}
+
+	return weights
+}
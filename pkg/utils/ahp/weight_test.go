@@ -0,0 +1,57 @@
+package ahp
+
+import (
+	"math"
+	"testing"
+)
+
+// saatyExample is the 3x3 example of Saaty (1980), its principal eigenvector is (0.637, 0.258, 0.105) with
+// lambda max 3.039 and CR 0.033.
+var saatyExample = [][]float64{
+	{1, 3, 5},
+	{1.0 / 3, 1, 3},
+	{1.0 / 5, 1.0 / 3, 1},
+}
+
+func TestWeightsReferenceValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		pairwise [][]float64
+		method   string
+		expected []float64
+	}{
+		{"saaty average", saatyExample, MethodAverage, []float64{0.633346, 0.260498, 0.106156}},
+		{"saaty eigenvector", saatyExample, MethodEigenvector, []float64{0.636986, 0.258285, 0.104729}},
+		{"saaty geometric mean", saatyExample, MethodGeometricMean, []float64{0.636986, 0.258285, 0.104729}},
+		{"consistent", [][]float64{{1, 2, 4}, {1.0 / 2, 1, 2}, {1.0 / 4, 1.0 / 2, 1}}, MethodAverage, []float64{4.0 / 7, 2.0 / 7, 1.0 / 7}},
+		{"consistent eigenvector", [][]float64{{1, 2, 4}, {1.0 / 2, 1, 2}, {1.0 / 4, 1.0 / 2, 1}}, MethodEigenvector, []float64{4.0 / 7, 2.0 / 7, 1.0 / 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights, _, err := Weights(tt.pairwise, tt.method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range tt.expected {
+				if math.Abs(weights[i]-tt.expected[i]) > 1e-6 {
+					t.Errorf("expected %v, got %v", tt.expected, weights)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestWeightsSaatyConsistency(t *testing.T) {
+	weights, _, err := Weights(saatyExample, MethodEigenvector)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lambdaMax := LambdaMax(saatyExample, weights)
+	cr := ConsistencyRatio(ConsistencyIndex(lambdaMax, 3), 3)
+	if math.Abs(lambdaMax-3.038511) > 1e-6 || math.Abs(cr-0.0332) > 1e-4 {
+		t.Errorf("expected lambda max 3.0385 and CR 0.0332, got %v and %v", lambdaMax, cr)
+	}
+}
@@ -1,13 +1,20 @@
 package validator
 
-import "github.com/go-playground/validator/v10"
+import (
+	"github.com/go-playground/validator/v10"
+	"ta13-svc/pkg/utils/ahp"
+)
 
 type CustomValidator struct {
 	Validator *validator.Validate
 }
 
 func NewValidator() *validator.Validate {
-	return validator.New()
+	v := validator.New()
+	_ = v.RegisterValidation("weight_method", func(fl validator.FieldLevel) bool {
+		return ahp.IsWeightMethod(fl.Field().String())
+	})
+	return v
 }
 
 func (cv *CustomValidator) Validate(i interface{}) error {
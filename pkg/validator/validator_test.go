@@ -0,0 +1,21 @@
+package validator
+
+import "testing"
+
+func TestWeightMethod(t *testing.T) {
+	type request struct {
+		Method string `validate:"omitempty,weight_method"`
+	}
+
+	v := NewValidator()
+	for _, method := range []string{"", "average", "eigenvector", "geometric_mean", "fuzzy_extent", "fuzzy_geometric_mean"} {
+		if err := v.Struct(request{Method: method}); err != nil {
+			t.Errorf("%q: %v", method, err)
+		}
+	}
+	for _, method := range []string{"bwm", "saaty", "Eigenvector"} {
+		if err := v.Struct(request{Method: method}); err == nil {
+			t.Errorf("%q: expected an error", method)
+		}
+	}
+}